@@ -0,0 +1,272 @@
+// Converts an HTML file to Go code that writes the same markup with snake.
+//
+// Usage:
+//
+//	html2snake [-func name] [-package name] [file.html]
+//
+// Reads standard input if no file is given. The output is a function that
+// takes an *snake.HtmlResponse, or a complete Go file if -package is given.
+// Elements and attributes that snake has no method or function for are
+// written with Element() and Attr(), and comments with RawText().
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/lkesteloot/goutil/snake"
+	"go/format"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Writes the Go statements for a tree.
+type converter struct {
+	buf bytes.Buffer
+
+	// Qualifier for snake's package-level functions, such as "snake.".
+	qualifier string
+}
+
+// Elements whose whitespace is significant.
+var preformatted = map[string]bool{
+	"pre":      true,
+	"textarea": true,
+	"script":   true,
+	"style":    true,
+}
+
+// Return the Go expressions for the element's attributes, comma-separated.
+func (c *converter) attrs(n *snake.Node) string {
+	var exprs []string
+
+	for _, a := range n.Attrs() {
+		name := a.Name()
+		value := strconv.Quote(a.Value())
+		info, ok := snake.LookupAttr(name)
+
+		var expr string
+		switch {
+		case ok && info.Boolean:
+			expr = fmt.Sprintf("%s%s()", c.qualifier, info.Function)
+		case ok:
+			expr = fmt.Sprintf("%s%s(%s)", c.qualifier, info.Function, value)
+		case strings.HasPrefix(name, "data-"):
+			expr = fmt.Sprintf("%sData(%q, %s)", c.qualifier, name[5:], value)
+		case strings.HasPrefix(name, "aria-"):
+			expr = fmt.Sprintf("%sAria(%q, %s)", c.qualifier, name[5:], value)
+		case a.IsBoolean():
+			expr = fmt.Sprintf("%sBoolAttr(%q)", c.qualifier, name)
+		default:
+			expr = fmt.Sprintf("%sAttr(%q, %s)", c.qualifier, name, value)
+		}
+
+		// The values come from the HTML file, so they're written as they are.
+		if !a.IsBoolean() && snake.IsSanitizedAttr(name) {
+			expr = fmt.Sprintf("%sTrust(%s)", c.qualifier, expr)
+		}
+		exprs = append(exprs, expr)
+	}
+
+	return strings.Join(exprs, ", ")
+}
+
+// Write a statement.
+func (c *converter) line(format string, args ...interface{}) {
+	fmt.Fprintf(&c.buf, format, args...)
+	c.buf.WriteString("\n")
+}
+
+// Return the children that matter, dropping the whitespace between tags that
+// comes from the file's indentation. Whitespace next to a block element, or
+// at the start or end of one, doesn't show, so it's dropped; between inline
+// elements it's a space. In SVG and MathML (foreign) it's always dropped.
+func significantChildren(n *snake.Node, foreign, pre bool) []*snake.Node {
+	var children []*snake.Node
+
+	for i, child := range n.Children {
+		if !pre && child.Type == snake.TextNode &&
+			strings.TrimSpace(child.Text) == "" && strings.Contains(child.Text, "\n") {
+
+			var before, after *snake.Node
+			if i > 0 {
+				before = n.Children[i-1]
+			}
+			if i < len(n.Children)-1 {
+				after = n.Children[i+1]
+			}
+			if foreign || blockEdge(n, before) || blockEdge(n, after) {
+				continue
+			}
+			child = &snake.Node{Type: snake.TextNode, Text: " "}
+		}
+		children = append(children, child)
+	}
+
+	return children
+}
+
+// Whether whitespace next to the sibling of a child of the parent is
+// invisible, because the sibling is a block element or there's no sibling
+// and the parent is a block.
+func blockEdge(parent, sibling *snake.Node) bool {
+	if sibling == nil {
+		return parent.Type != snake.ElementNode || isBlock(parent)
+	}
+
+	return sibling.Type == snake.ElementNode && isBlock(sibling)
+}
+
+func isBlock(n *snake.Node) bool {
+	info, _ := snake.LookupElement(n.Tag, false)
+	return info.Block
+}
+
+// Write the statements for the node and everything under it. Foreign is
+// true inside <svg> and <math>, and pre inside whitespace-sensitive elements.
+func (c *converter) convert(n *snake.Node, foreign, pre bool) {
+	switch n.Type {
+	case snake.DocumentNode:
+		for _, child := range significantChildren(n, foreign, pre) {
+			c.convert(child, foreign, pre)
+		}
+	case snake.DoctypeNode:
+		c.line("h.Doctype()")
+	case snake.TextNode:
+		c.line("h.Text(%s)", strconv.Quote(n.Text))
+	case snake.RawNode:
+		c.line("h.RawText(%s)", strconv.Quote(n.Text))
+	case snake.ElementNode:
+		c.convertElement(n, foreign, pre)
+	}
+}
+
+func (c *converter) convertElement(n *snake.Node, foreign, pre bool) {
+	foreign = foreign || n.Tag == "svg" || n.Tag == "math"
+	pre = pre || (!foreign && preformatted[n.Tag])
+	attrs := c.attrs(n)
+	children := significantChildren(n, foreign, pre)
+
+	info, known := snake.LookupElement(n.Tag, foreign)
+
+	// Elements without a closing tag.
+	if known && info.Void {
+		c.line("h.%s(%s)", info.Method, attrs)
+		return
+	}
+	if len(children) == 0 && (info.SelfClosing || (!known && foreign)) {
+		if known {
+			c.line("h.%s(%s)", info.Method, attrs)
+		} else {
+			c.line("h.EmptyElement(%s)", joinArgs(strconv.Quote(n.Tag), attrs))
+		}
+		return
+	}
+
+	// Elements with a closing tag.
+	var open, close string
+	if known && !info.SelfClosing {
+		open = fmt.Sprintf("h.%s(%s)", info.Method, attrs)
+		close = info.Method + "_()"
+	} else {
+		open = fmt.Sprintf("h.Element(%s)", joinArgs(strconv.Quote(n.Tag), attrs))
+		close = fmt.Sprintf("Element_(%s)", strconv.Quote(n.Tag))
+	}
+
+	switch {
+	case len(children) == 0:
+		c.line("%s.%s", open, close)
+	case len(children) == 1 && children[0].Type == snake.TextNode:
+		c.line("%s.Content(%s)", open, strconv.Quote(children[0].Text))
+	case len(children) == 1 && children[0].Type == snake.RawNode:
+		c.line("%s.RawContent(%s)", open, strconv.Quote(children[0].Text))
+	default:
+		c.line("%s", open)
+		for _, child := range children {
+			c.convert(child, foreign, pre)
+		}
+		c.line("h.%s", close)
+	}
+}
+
+// Join non-empty function arguments.
+func joinArgs(args ...string) string {
+	var nonEmpty []string
+	for _, arg := range args {
+		if arg != "" {
+			nonEmpty = append(nonEmpty, arg)
+		}
+	}
+
+	return strings.Join(nonEmpty, ", ")
+}
+
+// Return the formatted Go source of a function named funcName that writes
+// the tree, in a complete file if packageName isn't empty.
+func convertFile(root *snake.Node, funcName, packageName string) ([]byte, error) {
+	c := &converter{qualifier: "snake."}
+	if packageName == "snake" {
+		c.qualifier = ""
+	}
+
+	if packageName != "" {
+		c.line("package %s", packageName)
+		if c.qualifier != "" {
+			c.line("import %q", "github.com/lkesteloot/goutil/snake")
+		}
+	}
+	c.line("func %s(h *%sHtmlResponse) {", funcName, c.qualifier)
+	c.convert(root, false, false)
+	c.line("}")
+
+	src, err := format.Source(c.buf.Bytes())
+	if err != nil {
+		// Show the unformatted code so the problem can be found.
+		return nil, fmt.Errorf("%v in:\n%s", err, c.buf.Bytes())
+	}
+
+	return src, nil
+}
+
+func main() {
+	funcName := flag.String("func", "render", "name of the generated function")
+	packageName := flag.String("package", "", "write a complete file in this package")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: html2snake [flags] [file.html]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var r io.Reader = os.Stdin
+	switch flag.NArg() {
+	case 0:
+		// Read standard input.
+	case 1:
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		r = f
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	root, err := snake.Parse(r)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	src, err := convertFile(root, *funcName, *packageName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	os.Stdout.Write(src)
+}
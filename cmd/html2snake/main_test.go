@@ -0,0 +1,105 @@
+package main
+
+import (
+	"github.com/lkesteloot/goutil/snake"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Runs the generated function and prints what it writes.
+const driver = `package main
+
+import (
+	"github.com/lkesteloot/goutil/snake"
+	"os"
+)
+
+func main() {
+	h := snake.New(os.Stdout)
+	render(h)
+	if err := h.Close(); err != nil {
+		panic(err)
+	}
+}
+`
+
+func TestRoundTrip(t *testing.T) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+
+	input := `<div class="menu" style="color: red" onclick="toggle('menu')">` +
+		`<a href="javascript:void(0)" data-id="7">Menu</a>` +
+		`<input type="checkbox" checked>` +
+		`<svg viewBox="0 0 1 1"><path d="M0 0"/></svg>` +
+		`<script>if (a < b) alert("hi");</script>` +
+		`</div>`
+
+	root, err := snake.Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := convertFile(root, "render", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	for name, contents := range map[string]string{"render.go": string(src), "main.go": driver} {
+		err = os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(goTool, "run", "main.go", "render.go")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v: %s\n%s", err, out, src)
+	}
+
+	// Only the quotes are escaped differently.
+	expected := strings.ReplaceAll(input, "toggle('menu')", "toggle(&#39;menu&#39;)")
+	if string(out) != expected {
+		t.Errorf("got\n%s\nexpected\n%s\nfrom\n%s", out, expected, src)
+	}
+}
+
+func TestWhitespace(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"<div>\n  <span>a</span>\n  <span>b</span>\n</div>", []string{"span", " ", "span"}},
+		{"<div>\n  <p>a</p>\n  <span>b</span>\n</div>", []string{"p", "span"}},
+		{"<span>\n<b>a</b>\n</span>", []string{" ", "b", " "}},
+		{"<pre>\n<b>a</b>\n</pre>", []string{"\n", "b", "\n"}},
+		{"<svg>\n<g></g>\n</svg>", []string{"g"}},
+	}
+
+	for _, test := range tests {
+		root, err := snake.Parse(strings.NewReader(test.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		parent := root.Children[0]
+		foreign := parent.Tag == "svg"
+		var got []string
+		for _, child := range significantChildren(parent, foreign, parent.Tag == "pre") {
+			if child.Type == snake.TextNode {
+				got = append(got, child.Text)
+			} else {
+				got = append(got, child.Tag)
+			}
+		}
+		if strings.Join(got, "|") != strings.Join(test.expected, "|") {
+			t.Errorf("%q: got %q, expected %q", test.input, got, test.expected)
+		}
+	}
+}
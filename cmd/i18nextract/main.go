@@ -0,0 +1,186 @@
+// Extracts the message keys passed to snake's T() and i18n's Translate()
+// from Go source, for making or updating message catalogs.
+//
+// Usage:
+//
+//	i18nextract [-format json|pot] [-merge catalog.json] [dir ...]
+//
+// A directory ending in "/..." includes its subdirectories. The JSON format
+// writes a catalog with each key as its own message, keeping the messages of
+// the -merge catalog and listing its unused keys on standard error. The pot
+// format writes a gettext template with the source location of each key.
+// Only keys written as string literals are found.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Names of the methods whose first argument is a message key.
+var translateMethods = map[string]bool{
+	"T":         true,
+	"Translate": true,
+}
+
+// Source locations of each key.
+type keys map[string][]string
+
+// Add the keys used in the Go file.
+func (k keys) extractFile(fset *token.FileSet, path string) error {
+	f, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !translateMethods[sel.Sel.Name] {
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+
+		key, err := strconv.Unquote(lit.Value)
+		if err == nil {
+			pos := fset.Position(lit.Pos())
+			k[key] = append(k[key], fmt.Sprintf("%s:%d", filepath.ToSlash(pos.Filename), pos.Line))
+		}
+		return true
+	})
+
+	return nil
+}
+
+// Add the keys used in the directory's Go files, and its subdirectories' if
+// it ends in "/...".
+func (k keys) extractDir(fset *token.FileSet, dir string) error {
+	recursive := strings.HasSuffix(dir, "/...")
+	if recursive {
+		dir = strings.TrimSuffix(dir, "/...")
+	}
+
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && (!recursive || strings.HasPrefix(d.Name(), ".") || d.Name() == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		return k.extractFile(fset, path)
+	})
+}
+
+// Write a JSON catalog of the keys, keeping the messages of the merged
+// catalog if there is one.
+func writeJson(w io.Writer, k keys, mergePath string) error {
+	catalog := make(map[string]json.RawMessage)
+
+	if mergePath != "" {
+		data, err := os.ReadFile(mergePath)
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal(data, &catalog)
+		if err != nil {
+			return fmt.Errorf("%s: %s", mergePath, err)
+		}
+
+		for key := range catalog {
+			if _, ok := k[key]; !ok {
+				fmt.Fprintf(os.Stderr, "unused key: %q\n", key)
+			}
+		}
+	}
+
+	for key := range k {
+		if _, ok := catalog[key]; !ok {
+			catalog[key], _ = json.Marshal(key)
+		}
+	}
+
+	// Maps are written with sorted keys.
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// Write a gettext template of the keys.
+func writePot(w io.Writer, k keys) {
+	var sorted []string
+	for key := range k {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	fmt.Fprintf(w, "msgid \"\"\nmsgstr \"\"\n\"Content-Type: text/plain; charset=UTF-8\\n\"\n")
+	for _, key := range sorted {
+		fmt.Fprintf(w, "\n#: %s\nmsgid %s\nmsgstr \"\"\n", strings.Join(k[key], " "), strconv.Quote(key))
+	}
+}
+
+func main() {
+	format := flag.String("format", "json", "output format, json or pot")
+	mergePath := flag.String("merge", "", "JSON catalog whose messages to keep")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: i18nextract [flags] [dir ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	fset := token.NewFileSet()
+	k := make(keys)
+	for _, dir := range dirs {
+		err := k.extractDir(fset, dir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	switch *format {
+	case "json":
+		err := writeJson(os.Stdout, k, *mergePath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	case "pot":
+		writePot(os.Stdout, k)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Write the files, by slash-separated path, under a new directory.
+func writeTree(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err == nil {
+			err = os.WriteFile(path, []byte(contents), 0o644)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestExtract(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"page.go": `package page
+
+func render(h *snake.HtmlResponse, c *i18n.Catalog, key string) {
+	h.T("cart.title")
+	h.T("cart.items", 3)
+	c.Translate("cart.title")
+	h.T(key)
+	h.T(` + "`raw`" + `)
+	fmt.Println("not a key")
+}
+`,
+		"page_test.go":      `package page; func f() { h.T("test.only") }`,
+		"sub/sub.go":        `package sub; func f() { h.T("sub.key") }`,
+		"sub/testdata/x.go": `package x; func f() { h.T("testdata.key") }`,
+		".hidden/hidden.go": `package hidden; func f() { h.T("hidden.key") }`,
+		"sub/notes.txt":     `h.T("not.go")`,
+	})
+
+	k := make(keys)
+	if err := k.extractDir(token.NewFileSet(), dir); err != nil {
+		t.Fatal(err)
+	}
+	page := filepath.ToSlash(filepath.Join(dir, "page.go"))
+	expected := keys{
+		"cart.title": {page + ":4", page + ":6"},
+		"cart.items": {page + ":5"},
+		"raw":        {page + ":8"},
+	}
+	if !reflect.DeepEqual(k, expected) {
+		t.Errorf("got %v, expected %v", k, expected)
+	}
+
+	k = make(keys)
+	if err := k.extractDir(token.NewFileSet(), filepath.ToSlash(dir)+"/..."); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := k["sub.key"]; !ok || len(k) != 4 {
+		t.Errorf("expected the subdirectory's key only, got %v", k)
+	}
+
+	if err := make(keys).extractDir(token.NewFileSet(), filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	k := keys{
+		"b": {"page.go:2"},
+		"a": {"page.go:1", "other.go:7"},
+	}
+
+	var buf bytes.Buffer
+	writePot(&buf, k)
+	expected := "msgid \"\"\nmsgstr \"\"\n\"Content-Type: text/plain; charset=UTF-8\\n\"\n" +
+		"\n#: page.go:1 other.go:7\nmsgid \"a\"\nmsgstr \"\"\n" +
+		"\n#: page.go:2\nmsgid \"b\"\nmsgstr \"\"\n"
+	if buf.String() != expected {
+		t.Errorf("got\n%s\nexpected\n%s", buf.String(), expected)
+	}
+
+	dir := writeTree(t, map[string]string{
+		"fr.json": `{"a": "A traduit", "old": "Vieux"}`,
+	})
+	buf.Reset()
+	if err := writeJson(&buf, k, filepath.Join(dir, "fr.json")); err != nil {
+		t.Fatal(err)
+	}
+	expected = "{\n  \"a\": \"A traduit\",\n  \"b\": \"b\",\n  \"old\": \"Vieux\"\n}\n"
+	if buf.String() != expected {
+		t.Errorf("got\n%s\nexpected\n%s", buf.String(), expected)
+	}
+
+	if err := writeJson(&buf, k, filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected an error for a missing catalog")
+	}
+}
@@ -48,3 +48,13 @@ func ParseIdField(s string, defaultValue IdField) IdField {
 	}
 	return IdField(id)
 }
+
+// Return the plain text to show in an HTML form field. This is the inverse of
+// ParseIdField(). A null ID is an empty string.
+func (id IdField) ToTextField() string {
+	if id == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(int(id))
+}
@@ -0,0 +1,297 @@
+// Generates RSS 2.0 and Atom 1.0 feeds.
+package feed
+
+import (
+	"fmt"
+	"github.com/lkesteloot/goutil/snake"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// A feed and its entries. Entries should be newest first.
+type Feed struct {
+	Title       string
+	Description string
+
+	// URL of the site the feed is for.
+	Link string
+
+	// URL of the feed itself. Also used as the Atom feed ID.
+	FeedUrl string
+
+	// Default author for entries that don't have one.
+	Author string
+
+	// If zero, the most recent entry time is used.
+	Updated time.Time
+
+	Entries []*Entry
+}
+
+// One item of a feed.
+type Entry struct {
+	Title string
+	Link  string
+
+	// Unique, permanent ID. Defaults to Link.
+	Id string
+
+	Author    string
+	Published time.Time
+
+	// If zero, Published is used.
+	Updated time.Time
+
+	// Plain-text summary and HTML content. Either may be empty.
+	Summary string
+	Content string
+}
+
+// Feed formats.
+type Format int
+
+const (
+	Rss Format = iota
+	Atom
+)
+
+// Return the MIME type for the format.
+func (f Format) ContentType() string {
+	if f == Atom {
+		return "application/atom+xml; charset=utf-8"
+	}
+
+	return "application/rss+xml; charset=utf-8"
+}
+
+func (e *Entry) id() string {
+	if e.Id != "" {
+		return e.Id
+	}
+
+	return e.Link
+}
+
+func (e *Entry) updated() time.Time {
+	if e.Updated.IsZero() {
+		return e.Published
+	}
+
+	return e.Updated
+}
+
+// Return the feed's update time, or that of its latest entry.
+func (f *Feed) updated() time.Time {
+	updated := f.Updated
+
+	if updated.IsZero() {
+		for _, e := range f.Entries {
+			if e.updated().After(updated) {
+				updated = e.updated()
+			}
+		}
+	}
+
+	return updated
+}
+
+// Return the text without the control characters that XML doesn't allow,
+// even escaped.
+func xmlText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if (r < 0x20 && r != '\t' && r != '\n' && r != '\r') || r == 0xFFFE || r == 0xFFFF {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// Write an element containing only text.
+func textElement(h *snake.HtmlResponse, tag, text string) {
+	h.Element(tag).Text(xmlText(text)).Element_(tag)
+}
+
+// Write the feed in the format.
+func (f *Feed) Write(w io.Writer, format Format) error {
+	if format == Atom {
+		return f.WriteAtom(w)
+	}
+
+	return f.WriteRss(w)
+}
+
+// Write the feed as RSS 2.0.
+func (f *Feed) WriteRss(w io.Writer) error {
+	h := snake.New(w, snake.WithXml(), snake.WithOutputMode(snake.OutputPretty))
+
+	h.XmlDeclaration().
+		Element("rss", snake.Attr("version", "2.0"), snake.XmlnsPrefix("atom", snake.AtomNamespace)).
+		Element("channel")
+
+	textElement(h, "title", f.Title)
+	textElement(h, "link", f.Link)
+	textElement(h, "description", f.Description)
+	if f.FeedUrl != "" {
+		h.EmptyElement("atom:link", snake.Href(xmlText(f.FeedUrl)), snake.Rel("self"),
+			snake.Type("application/rss+xml"))
+	}
+	if updated := f.updated(); !updated.IsZero() {
+		textElement(h, "lastBuildDate", updated.Format(time.RFC1123Z))
+	}
+
+	for _, e := range f.Entries {
+		h.Element("item")
+		textElement(h, "title", e.Title)
+		if e.Link != "" {
+			textElement(h, "link", e.Link)
+		}
+		if id := e.id(); id != "" {
+			isPermaLink := "false"
+			if id == e.Link {
+				isPermaLink = "true"
+			}
+			h.Element("guid", snake.Attr("isPermaLink", isPermaLink)).Text(xmlText(id)).Element_("guid")
+		}
+		if !e.Published.IsZero() {
+			textElement(h, "pubDate", e.Published.Format(time.RFC1123Z))
+		}
+
+		// RSS only has the one field, which may contain HTML.
+		description := e.Content
+		if description == "" {
+			description = e.Summary
+		}
+		if description != "" {
+			textElement(h, "description", description)
+		}
+
+		h.Element_("item")
+	}
+
+	h.Element_("channel").Element_("rss")
+
+	return h.Close()
+}
+
+// Return an error if the feed lacks something Atom requires: an ID for the
+// feed and each entry, and an author for each entry.
+func (f *Feed) checkAtom() error {
+	if f.atomId() == "" {
+		return fmt.Errorf("Atom feed needs a FeedUrl or Link for its ID")
+	}
+
+	for _, e := range f.Entries {
+		if e.id() == "" {
+			return fmt.Errorf("Atom entry %q needs a Link or Id", e.Title)
+		}
+		if f.Author == "" && e.Author == "" {
+			return fmt.Errorf("Atom entry %q needs an Author, since the feed has none", e.Title)
+		}
+	}
+
+	return nil
+}
+
+func (f *Feed) atomId() string {
+	if f.FeedUrl != "" {
+		return f.FeedUrl
+	}
+
+	return f.Link
+}
+
+// Write the feed as Atom 1.0. Nothing is written if the feed lacks
+// something Atom requires.
+func (f *Feed) WriteAtom(w io.Writer) error {
+	err := f.checkAtom()
+	if err != nil {
+		return err
+	}
+
+	h := snake.New(w, snake.WithXml(), snake.WithOutputMode(snake.OutputPretty))
+
+	// Atom requires dates, so undated feeds use the time they're written,
+	// and undated entries use the feed's date.
+	updated := f.updated()
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+
+	h.XmlDeclaration().Element("feed", snake.Xmlns(snake.AtomNamespace))
+	textElement(h, "title", f.Title)
+	if f.Description != "" {
+		textElement(h, "subtitle", f.Description)
+	}
+	if f.Link != "" {
+		h.EmptyElement("link", snake.Href(xmlText(f.Link)))
+	}
+	if f.FeedUrl != "" {
+		h.EmptyElement("link", snake.Rel("self"), snake.Href(xmlText(f.FeedUrl)))
+	}
+	textElement(h, "id", f.atomId())
+	textElement(h, "updated", updated.UTC().Format(time.RFC3339))
+	writeAtomAuthor(h, f.Author)
+
+	for _, e := range f.Entries {
+		h.Element("entry")
+		textElement(h, "title", e.Title)
+		if e.Link != "" {
+			h.EmptyElement("link", snake.Href(xmlText(e.Link)))
+		}
+		textElement(h, "id", e.id())
+		entryUpdated := e.updated()
+		if entryUpdated.IsZero() {
+			entryUpdated = updated
+		}
+		textElement(h, "updated", entryUpdated.UTC().Format(time.RFC3339))
+		if !e.Published.IsZero() {
+			textElement(h, "published", e.Published.UTC().Format(time.RFC3339))
+		}
+		writeAtomAuthor(h, e.Author)
+		if e.Summary != "" {
+			textElement(h, "summary", e.Summary)
+		}
+		if e.Content != "" {
+			h.Element("content", snake.Type("html")).Text(xmlText(e.Content)).Element_("content")
+		}
+		h.Element_("entry")
+	}
+
+	h.Element_("feed")
+
+	return h.Close()
+}
+
+func writeAtomAuthor(h *snake.HtmlResponse, name string) {
+	if name != "" {
+		h.Element("author")
+		textElement(h, "name", name)
+		h.Element_("author")
+	}
+}
+
+// Handler that serves the feed returned by getFeed in the format.
+func Handler(format Format, getFeed func(r *http.Request) (*Feed, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, err := getFeed(r)
+		if err == nil && format == Atom {
+			err = f.checkAtom()
+		}
+		if err != nil {
+			log.Printf("Can't get feed for %s: %s", r.URL, err)
+			http.Error(w,
+				http.StatusText(http.StatusInternalServerError),
+				http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", format.ContentType())
+		err = f.Write(w, format)
+		if err != nil {
+			log.Printf("Can't write feed for %s: %s", r.URL, err)
+		}
+	})
+}
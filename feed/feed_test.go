@@ -0,0 +1,122 @@
+package feed
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testFeed() *Feed {
+	published := time.Date(2014, 3, 5, 17, 4, 5, 0, time.FixedZone("PST", -8*3600))
+
+	return &Feed{
+		Title:   "Tom & Jerry",
+		Link:    "https://example.com/",
+		FeedUrl: "https://example.com/feed",
+		Author:  "Tom",
+		Entries: []*Entry{{
+			Title:     "First <post>",
+			Link:      "https://example.com/1",
+			Published: published,
+			Content:   "<p>Hi</p>",
+		}},
+	}
+}
+
+func TestRss(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testFeed().WriteRss(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		`<title>Tom &amp; Jerry</title>`,
+		`<link>https://example.com/</link>`,
+		`<link>https://example.com/1</link>`,
+		`<atom:link href="https://example.com/feed" rel="self" type="application/rss+xml"/>`,
+		`<lastBuildDate>Wed, 05 Mar 2014 17:04:05 -0800</lastBuildDate>`,
+		`<guid isPermaLink="true">https://example.com/1</guid>`,
+		`<description>&lt;p&gt;Hi&lt;/p&gt;</description>`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("RSS missing %s:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestAtom(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testFeed().WriteAtom(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom">`,
+		`<id>https://example.com/feed</id>`,
+		`<updated>2014-03-06T01:04:05Z</updated>`,
+		`<title>First &lt;post&gt;</title>`,
+		`<content type="html">&lt;p&gt;Hi&lt;/p&gt;</content>`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Atom missing %s:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestAtomWithoutDates(t *testing.T) {
+	f := testFeed()
+	f.Entries[0].Published = time.Time{}
+
+	var buf bytes.Buffer
+	if err := f.WriteAtom(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(buf.String(), "0001-01-01") {
+		t.Errorf("Atom has a zero date:\n%s", buf.String())
+	}
+}
+
+func TestAtomRequirements(t *testing.T) {
+	f := testFeed()
+	f.Entries[0].Link = ""
+
+	var buf bytes.Buffer
+	if err := f.WriteAtom(&buf); err == nil {
+		t.Error("expected an error for an entry without an ID")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("wrote an invalid feed:\n%s", buf.String())
+	}
+
+	f = testFeed()
+	f.Author = ""
+	if err := f.WriteAtom(&buf); err == nil {
+		t.Error("expected an error for an entry without an author")
+	}
+
+	f.Entries[0].Author = "Jerry"
+	if err := f.WriteAtom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "<name>Jerry</name>") {
+		t.Errorf("Atom missing the entry's author:\n%s", buf.String())
+	}
+}
+
+func TestControlCharacters(t *testing.T) {
+	f := testFeed()
+	f.Title = "Tom\x00 &\x1b Jerry\tand\x7f"
+
+	for _, format := range []Format{Rss, Atom} {
+		var buf bytes.Buffer
+		if err := f.Write(&buf, format); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(buf.String(), "<title>Tom &amp; Jerry\tand\x7f</title>") {
+			t.Errorf("%s: control characters not stripped:\n%s", format.ContentType(), buf.String())
+		}
+	}
+}
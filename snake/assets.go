@@ -0,0 +1,169 @@
+package snake
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// One file of an asset manifest.
+type asset struct {
+	// Name relative to the manifest's directory, such as "js/app.js".
+	name string
+
+	// Name with the content hash, such as "js/app.1f3a9c0b2d4e.js".
+	hashedName string
+
+	// Subresource Integrity hash, such as "sha384-...".
+	integrity string
+}
+
+// Maps logical asset names, like "js/app.js", to file names that include a
+// hash of their contents, like "js/app.1f3a9c0b2d4e.js", and to their
+// Subresource Integrity hashes. Since a file's URL changes whenever it does,
+// the hashed files can be cached forever. Serve them with
+// webutil.AssetHandler().
+type AssetManifest struct {
+	dir       string
+	urlPrefix string
+
+	// By logical name and by hashed name.
+	byName       map[string]*asset
+	byHashedName map[string]*asset
+}
+
+// Number of hex digits of the content hash put in file names.
+const assetHashLength = 12
+
+// Hash every file under dir, typically at startup. URLs are the hashed names
+// after urlPrefix, which is a path like "/assets/" or a CDN URL like
+// "https://cdn.example.com/assets/". Files and directories starting with
+// "." are skipped.
+func LoadAssets(dir, urlPrefix string) (*AssetManifest, error) {
+	m := &AssetManifest{
+		dir:          dir,
+		urlPrefix:    strings.TrimSuffix(urlPrefix, "/") + "/",
+		byName:       make(map[string]*asset),
+		byHashedName: make(map[string]*asset),
+	}
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") && p != dir {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+
+		sum := sha512.Sum384(data)
+		a := &asset{
+			name:       name,
+			hashedName: hashedAssetName(name, hex.EncodeToString(sum[:])[:assetHashLength]),
+			integrity:  "sha384-" + base64.StdEncoding.EncodeToString(sum[:]),
+		}
+		m.byName[name] = a
+		m.byHashedName[a.hashedName] = a
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Put the hash before the file's extension.
+func hashedAssetName(name, hash string) string {
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + hash + ext
+}
+
+// Return the URL of the asset's hashed file, or name unchanged if it's not
+// an asset. A leading slash in name is ignored.
+func (m *AssetManifest) Url(name string) string {
+	a, ok := m.byName[strings.TrimPrefix(name, "/")]
+	if !ok {
+		return name
+	}
+
+	return m.urlPrefix + a.hashedName
+}
+
+// Return the asset's Subresource Integrity hash, or "" if it's not an asset.
+func (m *AssetManifest) Integrity(name string) string {
+	a, ok := m.byName[strings.TrimPrefix(name, "/")]
+	if !ok {
+		return ""
+	}
+
+	return a.integrity
+}
+
+// Return the path on disk of the file with the hashed name, such as
+// "js/app.1f3a9c0b2d4e.js".
+func (m *AssetManifest) FilePath(hashedName string) (string, bool) {
+	a, ok := m.byHashedName[hashedName]
+	if !ok {
+		return "", false
+	}
+
+	return filepath.Join(m.dir, filepath.FromSlash(a.name)), true
+}
+
+// Use the manifest for ScriptLink() and StyleLink(): logical names are
+// replaced with the URLs of hashed files and integrity hashes are added.
+// Other URLs are written unchanged.
+func WithAssets(m *AssetManifest) Option {
+	return func(h *HtmlResponse) {
+		h.assets = m
+	}
+}
+
+// Return the attributes that link to the URL or asset, in the src or href
+// attribute.
+func (h *HtmlResponse) assetAttrs(urlAttr func(string) attr, url string) []attr {
+	if h.assets == nil {
+		return []attr{urlAttr(url)}
+	}
+
+	integrity := h.assets.Integrity(url)
+	if integrity == "" {
+		return []attr{urlAttr(url)}
+	}
+
+	// The crossorigin attribute makes the integrity check work for assets on
+	// a CDN.
+	return []attr{urlAttr(h.assets.Url(url)), Integrity(integrity), CrossOrigin("anonymous")}
+}
+
+// Write a script tag linking to the URL or asset.
+func (h *HtmlResponse) scriptTag(url string) *HtmlResponse {
+	return h.Script(h.assetAttrs(Src, url)...).Script_()
+}
+
+// Write a stylesheet link to the URL or asset.
+func (h *HtmlResponse) styleTag(url string) *HtmlResponse {
+	return h.Link(append([]attr{Rel("stylesheet")}, h.assetAttrs(Href, url)...)...)
+}
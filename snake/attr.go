@@ -1,54 +1,187 @@
 package snake
 
+import (
+	"fmt"
+	"strings"
+)
+
 type attr struct {
 	name  string
 	value string
+
+	// Whether to skip context-specific sanitizing. See Trust().
+	trusted bool
+
+	// Whether the attribute is written without a value. See BoolAttr().
+	boolean bool
 }
 
 func Attr(name string, value string) attr {
-	return attr{name, value}
+	return attr{name: name, value: value}
+}
+
+// Make a boolean attribute, which is written without a value ("disabled")
+// because its presence alone means true.
+func BoolAttr(name string) attr {
+	return attr{name: name, boolean: true}
+}
+
+// Return the attribute if cond is true, otherwise an attribute that's left
+// out when writing the tag. Useful for boolean attributes:
+//
+//	h.Button(AttrIf(!canSave, Disabled())).Content("Save")
+func AttrIf(cond bool, a attr) attr {
+	if !cond {
+		return attr{}
+	}
+
+	return a
+}
+
+// Return the attributes without those left out by AttrIf().
+func presentAttrs(attrs []attr) []attr {
+	var present []attr
+
+	for _, a := range attrs {
+		if a.name != "" {
+			present = append(present, a)
+		}
+	}
+
+	return present
 }
 
-func Class(class string) attr {
-	return Attr("class", class)
+func (a attr) Name() string {
+	return a.name
 }
 
-func Id(id string) attr {
-	return Attr("id", id)
+func (a attr) Value() string {
+	return a.value
 }
 
-func Rel(rel string) attr {
-	return Attr("rel", rel)
+// Return whether the attribute is written without a value.
+func (a attr) IsBoolean() bool {
+	return a.boolean
 }
 
-func Href(href string) attr {
-	return Attr("href", href)
+// The RDFa "property" attribute, used by Open Graph meta tags. Not part of
+// HTML5 proper, so it's not in the spec table.
+func Property(property string) attr {
+	return Attr("property", property)
 }
 
-func Src(src string) attr {
-	return Attr("src", src)
+// Make a "data-key" attribute.
+func Data(key, value string) attr {
+	return Attr("data-"+key, value)
 }
 
-func Title(title string) attr {
-	return Attr("title", title)
+// Make an "aria-key" attribute, such as Aria("label", "Close").
+func Aria(key, value string) attr {
+	return Attr("aria-"+key, value)
 }
 
-func TabIndex(tabindex string) attr {
-	return Attr("tabindex", tabindex)
+// Return the class name if cond is true, otherwise "", for Classes().
+func ClassIf(cond bool, name string) string {
+	if !cond {
+		return ""
+	}
+
+	return name
 }
 
-func Charset(tabindex string) attr {
-	return Attr("charset", tabindex)
+// Make a class attribute from class names, leaving out empty ones and
+// duplicates. If there are no names, the attribute is left out. Each
+// argument may hold several space-separated names:
+//
+//	Classes("btn", ClassIf(primary, "btn-primary"), extraClasses)
+func Classes(names ...string) attr {
+	seen := make(map[string]bool)
+	var classes []string
+
+	for _, name := range names {
+		for _, class := range strings.Fields(name) {
+			if !seen[class] {
+				seen[class] = true
+				classes = append(classes, class)
+			}
+		}
+	}
+
+	if len(classes) == 0 {
+		return attr{}
+	}
+	return Class(strings.Join(classes, " "))
 }
 
-func Property(tabindex string) attr {
-	return Attr("property", tabindex)
+// One CSS property of a Styles() attribute.
+type StyleProp struct {
+	Name  string
+	Value string
 }
 
-func Content(tabindex string) attr {
-	return Attr("content", tabindex)
+// Make a CSS property for Styles().
+func Prop(name string, value interface{}) StyleProp {
+	return StyleProp{Name: name, Value: fmt.Sprint(value)}
 }
 
-func Name(tabindex string) attr {
-	return Attr("name", tabindex)
+// Make a style attribute from CSS properties, in order. A property set more
+// than once keeps its first position and its last value, and properties with
+// an empty value are left out:
+//
+//	Styles(Prop("width", fmt.Sprintf("%d%%", percent)), Prop("color", color))
+//
+// Values are escaped so that they can't end their declaration, and values
+// that could run code or load resources (like "url(...)") are replaced with
+// a harmless placeholder. Properties with invalid names are dropped. Use
+// Trust(Style(...)) for styles you wrote yourself.
+func Styles(props ...StyleProp) attr {
+	var names []string
+	values := make(map[string]string)
+
+	for _, prop := range props {
+		// Custom properties are case-sensitive.
+		name := strings.TrimSpace(prop.Name)
+		if !strings.HasPrefix(name, "--") {
+			name = strings.ToLower(name)
+		}
+		if !isCssPropertyName(name) {
+			continue
+		}
+		if _, ok := values[name]; !ok {
+			names = append(names, name)
+		}
+		values[name] = prop.Value
+	}
+
+	var declarations []string
+	for _, name := range names {
+		value := strings.TrimSpace(values[name])
+		if value == "" {
+			continue
+		}
+		if sanitizeStyle(value) != value {
+			value = unsafeStyle
+		}
+		declarations = append(declarations, name+": "+escapeCssValue(value))
+	}
+
+	// Already sanitized, and the escapes would fail sanitizing again.
+	return Trust(Style(strings.Join(declarations, "; ")))
+}
+
+// Return whether the name is a CSS property, possibly a custom one ("--x").
+func isCssPropertyName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i := 0; i < len(name); i++ {
+		ch := name[i]
+		if !(ch >= 'a' && ch <= 'z') && !(ch >= 'A' && ch <= 'Z') && !(ch >= '0' && ch <= '9') &&
+			ch != '-' && ch != '_' {
+			return false
+		}
+	}
+
+	return true
 }
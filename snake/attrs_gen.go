@@ -0,0 +1,1567 @@
+// Code generated by gen_elements.go from spec/attributes.txt; DO NOT EDIT.
+
+package snake
+
+// Makes the global "accesskey" attribute.
+func AccessKey(value string) attr {
+	return Attr("accesskey", value)
+}
+
+// Makes the global "autocapitalize" attribute.
+func AutoCapitalize(value string) attr {
+	return Attr("autocapitalize", value)
+}
+
+// Makes the global boolean "autofocus" attribute.
+func AutoFocus() attr {
+	return BoolAttr("autofocus")
+}
+
+// Makes the global "class" attribute.
+func Class(value string) attr {
+	return Attr("class", value)
+}
+
+// Makes the global "contenteditable" attribute.
+func ContentEditable(value string) attr {
+	return Attr("contenteditable", value)
+}
+
+// Makes the global "dir" attribute.
+func Dir(value string) attr {
+	return Attr("dir", value)
+}
+
+// Makes the global "draggable" attribute.
+func Draggable(value string) attr {
+	return Attr("draggable", value)
+}
+
+// Makes the global "enterkeyhint" attribute.
+func EnterKeyHint(value string) attr {
+	return Attr("enterkeyhint", value)
+}
+
+// Makes the global boolean "hidden" attribute.
+func Hidden() attr {
+	return BoolAttr("hidden")
+}
+
+// Makes the global "id" attribute.
+func Id(value string) attr {
+	return Attr("id", value)
+}
+
+// Makes the global boolean "inert" attribute.
+func Inert() attr {
+	return BoolAttr("inert")
+}
+
+// Makes the global "inputmode" attribute.
+func InputMode(value string) attr {
+	return Attr("inputmode", value)
+}
+
+// Makes the global "is" attribute.
+func Is(value string) attr {
+	return Attr("is", value)
+}
+
+// Makes the global "itemid" attribute.
+func ItemId(value string) attr {
+	return Attr("itemid", value)
+}
+
+// Makes the global "itemprop" attribute.
+func ItemProp(value string) attr {
+	return Attr("itemprop", value)
+}
+
+// Makes the global "itemref" attribute.
+func ItemRef(value string) attr {
+	return Attr("itemref", value)
+}
+
+// Makes the global boolean "itemscope" attribute.
+func ItemScope() attr {
+	return BoolAttr("itemscope")
+}
+
+// Makes the global "itemtype" attribute.
+func ItemType(value string) attr {
+	return Attr("itemtype", value)
+}
+
+// Makes the global "lang" attribute.
+func Lang(value string) attr {
+	return Attr("lang", value)
+}
+
+// Makes the global "nonce" attribute.
+func Nonce(value string) attr {
+	return Attr("nonce", value)
+}
+
+// Makes the global "popover" attribute.
+func Popover(value string) attr {
+	return Attr("popover", value)
+}
+
+// Makes the global "role" attribute.
+func Role(value string) attr {
+	return Attr("role", value)
+}
+
+// Makes the global "slot" attribute.
+func Slot(value string) attr {
+	return Attr("slot", value)
+}
+
+// Makes the global "spellcheck" attribute.
+func SpellCheck(value string) attr {
+	return Attr("spellcheck", value)
+}
+
+// Makes the global "style" attribute.
+func Style(value string) attr {
+	return Attr("style", value)
+}
+
+// Makes the global "tabindex" attribute.
+func TabIndex(value string) attr {
+	return Attr("tabindex", value)
+}
+
+// Makes the global "title" attribute.
+func Title(value string) attr {
+	return Attr("title", value)
+}
+
+// Makes the global "translate" attribute.
+func Translate(value string) attr {
+	return Attr("translate", value)
+}
+
+// Makes the global "onabort" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnAbort(value string) attr {
+	return Attr("onabort", value)
+}
+
+// Makes the global "onanimationcancel" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnAnimationCancel(value string) attr {
+	return Attr("onanimationcancel", value)
+}
+
+// Makes the global "onanimationend" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnAnimationEnd(value string) attr {
+	return Attr("onanimationend", value)
+}
+
+// Makes the global "onanimationiteration" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnAnimationIteration(value string) attr {
+	return Attr("onanimationiteration", value)
+}
+
+// Makes the global "onanimationstart" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnAnimationStart(value string) attr {
+	return Attr("onanimationstart", value)
+}
+
+// Makes the global "onauxclick" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnAuxClick(value string) attr {
+	return Attr("onauxclick", value)
+}
+
+// Makes the global "onbeforeinput" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnBeforeInput(value string) attr {
+	return Attr("onbeforeinput", value)
+}
+
+// Makes the global "onblur" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnBlur(value string) attr {
+	return Attr("onblur", value)
+}
+
+// Makes the global "oncancel" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnCancel(value string) attr {
+	return Attr("oncancel", value)
+}
+
+// Makes the global "onchange" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnChange(value string) attr {
+	return Attr("onchange", value)
+}
+
+// Makes the global "onclick" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnClick(value string) attr {
+	return Attr("onclick", value)
+}
+
+// Makes the global "onclose" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnClose(value string) attr {
+	return Attr("onclose", value)
+}
+
+// Makes the global "oncontextmenu" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnContextMenu(value string) attr {
+	return Attr("oncontextmenu", value)
+}
+
+// Makes the global "oncopy" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnCopy(value string) attr {
+	return Attr("oncopy", value)
+}
+
+// Makes the global "oncut" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnCut(value string) attr {
+	return Attr("oncut", value)
+}
+
+// Makes the global "ondblclick" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnDblClick(value string) attr {
+	return Attr("ondblclick", value)
+}
+
+// Makes the global "ondrag" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnDrag(value string) attr {
+	return Attr("ondrag", value)
+}
+
+// Makes the global "ondragend" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnDragEnd(value string) attr {
+	return Attr("ondragend", value)
+}
+
+// Makes the global "ondragenter" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnDragEnter(value string) attr {
+	return Attr("ondragenter", value)
+}
+
+// Makes the global "ondragleave" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnDragLeave(value string) attr {
+	return Attr("ondragleave", value)
+}
+
+// Makes the global "ondragover" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnDragOver(value string) attr {
+	return Attr("ondragover", value)
+}
+
+// Makes the global "ondragstart" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnDragStart(value string) attr {
+	return Attr("ondragstart", value)
+}
+
+// Makes the global "ondrop" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnDrop(value string) attr {
+	return Attr("ondrop", value)
+}
+
+// Makes the global "onerror" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnError(value string) attr {
+	return Attr("onerror", value)
+}
+
+// Makes the global "onfocus" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnFocus(value string) attr {
+	return Attr("onfocus", value)
+}
+
+// Makes the global "ongotpointercapture" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnGotPointerCapture(value string) attr {
+	return Attr("ongotpointercapture", value)
+}
+
+// Makes the global "oninput" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnInput(value string) attr {
+	return Attr("oninput", value)
+}
+
+// Makes the global "oninvalid" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnInvalid(value string) attr {
+	return Attr("oninvalid", value)
+}
+
+// Makes the global "onkeydown" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnKeyDown(value string) attr {
+	return Attr("onkeydown", value)
+}
+
+// Makes the global "onkeypress" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnKeyPress(value string) attr {
+	return Attr("onkeypress", value)
+}
+
+// Makes the global "onkeyup" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnKeyUp(value string) attr {
+	return Attr("onkeyup", value)
+}
+
+// Makes the global "onload" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnLoad(value string) attr {
+	return Attr("onload", value)
+}
+
+// Makes the global "onlostpointercapture" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnLostPointerCapture(value string) attr {
+	return Attr("onlostpointercapture", value)
+}
+
+// Makes the global "onmousedown" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnMouseDown(value string) attr {
+	return Attr("onmousedown", value)
+}
+
+// Makes the global "onmouseenter" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnMouseEnter(value string) attr {
+	return Attr("onmouseenter", value)
+}
+
+// Makes the global "onmouseleave" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnMouseLeave(value string) attr {
+	return Attr("onmouseleave", value)
+}
+
+// Makes the global "onmousemove" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnMouseMove(value string) attr {
+	return Attr("onmousemove", value)
+}
+
+// Makes the global "onmouseout" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnMouseOut(value string) attr {
+	return Attr("onmouseout", value)
+}
+
+// Makes the global "onmouseover" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnMouseOver(value string) attr {
+	return Attr("onmouseover", value)
+}
+
+// Makes the global "onmouseup" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnMouseUp(value string) attr {
+	return Attr("onmouseup", value)
+}
+
+// Makes the global "onpaste" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnPaste(value string) attr {
+	return Attr("onpaste", value)
+}
+
+// Makes the global "onpointercancel" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnPointerCancel(value string) attr {
+	return Attr("onpointercancel", value)
+}
+
+// Makes the global "onpointerdown" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnPointerDown(value string) attr {
+	return Attr("onpointerdown", value)
+}
+
+// Makes the global "onpointerenter" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnPointerEnter(value string) attr {
+	return Attr("onpointerenter", value)
+}
+
+// Makes the global "onpointerleave" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnPointerLeave(value string) attr {
+	return Attr("onpointerleave", value)
+}
+
+// Makes the global "onpointermove" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnPointerMove(value string) attr {
+	return Attr("onpointermove", value)
+}
+
+// Makes the global "onpointerout" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnPointerOut(value string) attr {
+	return Attr("onpointerout", value)
+}
+
+// Makes the global "onpointerover" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnPointerOver(value string) attr {
+	return Attr("onpointerover", value)
+}
+
+// Makes the global "onpointerup" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnPointerUp(value string) attr {
+	return Attr("onpointerup", value)
+}
+
+// Makes the global "onreset" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnReset(value string) attr {
+	return Attr("onreset", value)
+}
+
+// Makes the global "onresize" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnResize(value string) attr {
+	return Attr("onresize", value)
+}
+
+// Makes the global "onscroll" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnScroll(value string) attr {
+	return Attr("onscroll", value)
+}
+
+// Makes the global "onselect" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnSelect(value string) attr {
+	return Attr("onselect", value)
+}
+
+// Makes the global "onsubmit" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnSubmit(value string) attr {
+	return Attr("onsubmit", value)
+}
+
+// Makes the global "ontoggle" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnToggle(value string) attr {
+	return Attr("ontoggle", value)
+}
+
+// Makes the global "ontouchcancel" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnTouchCancel(value string) attr {
+	return Attr("ontouchcancel", value)
+}
+
+// Makes the global "ontouchend" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnTouchEnd(value string) attr {
+	return Attr("ontouchend", value)
+}
+
+// Makes the global "ontouchmove" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnTouchMove(value string) attr {
+	return Attr("ontouchmove", value)
+}
+
+// Makes the global "ontouchstart" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnTouchStart(value string) attr {
+	return Attr("ontouchstart", value)
+}
+
+// Makes the global "ontransitioncancel" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnTransitionCancel(value string) attr {
+	return Attr("ontransitioncancel", value)
+}
+
+// Makes the global "ontransitionend" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnTransitionEnd(value string) attr {
+	return Attr("ontransitionend", value)
+}
+
+// Makes the global "ontransitionrun" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnTransitionRun(value string) attr {
+	return Attr("ontransitionrun", value)
+}
+
+// Makes the global "ontransitionstart" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnTransitionStart(value string) attr {
+	return Attr("ontransitionstart", value)
+}
+
+// Makes the global "onwheel" event handler attribute.
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnWheel(value string) attr {
+	return Attr("onwheel", value)
+}
+
+// Makes the "onbeforeunload" event handler attribute (body).
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnBeforeUnload(value string) attr {
+	return Attr("onbeforeunload", value)
+}
+
+// Makes the "onhashchange" event handler attribute (body).
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnHashChange(value string) attr {
+	return Attr("onhashchange", value)
+}
+
+// Makes the "onpagehide" event handler attribute (body).
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnPageHide(value string) attr {
+	return Attr("onpagehide", value)
+}
+
+// Makes the "onpageshow" event handler attribute (body).
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnPageShow(value string) attr {
+	return Attr("onpageshow", value)
+}
+
+// Makes the "onpopstate" event handler attribute (body).
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnPopState(value string) attr {
+	return Attr("onpopstate", value)
+}
+
+// Makes the "onunload" event handler attribute (body).
+// Wrap code you wrote in Trust(); untrusted handlers are tag errors.
+func OnUnload(value string) attr {
+	return Attr("onunload", value)
+}
+
+// Makes the "abbr" attribute (th).
+func Abbr(value string) attr {
+	return Attr("abbr", value)
+}
+
+// Makes the "accept" attribute (input).
+func Accept(value string) attr {
+	return Attr("accept", value)
+}
+
+// Makes the "accept-charset" attribute (form).
+func AcceptCharset(value string) attr {
+	return Attr("accept-charset", value)
+}
+
+// Makes the "action" attribute (form).
+func Action(value string) attr {
+	return Attr("action", value)
+}
+
+// Makes the "allow" attribute (iframe).
+func Allow(value string) attr {
+	return Attr("allow", value)
+}
+
+// Makes the boolean "allowfullscreen" attribute (iframe).
+func AllowFullscreen() attr {
+	return BoolAttr("allowfullscreen")
+}
+
+// Makes the "alt" attribute (area, img, input).
+func Alt(value string) attr {
+	return Attr("alt", value)
+}
+
+// Makes the "as" attribute (link).
+func As(value string) attr {
+	return Attr("as", value)
+}
+
+// Makes the boolean "async" attribute (script).
+func Async() attr {
+	return BoolAttr("async")
+}
+
+// Makes the "autocomplete" attribute (form, input, select, textarea).
+func AutoComplete(value string) attr {
+	return Attr("autocomplete", value)
+}
+
+// Makes the boolean "autoplay" attribute (audio, video).
+func AutoPlay() attr {
+	return BoolAttr("autoplay")
+}
+
+// Makes the "blocking" attribute (link, script, style).
+func Blocking(value string) attr {
+	return Attr("blocking", value)
+}
+
+// Makes the "charset" attribute (meta).
+func Charset(value string) attr {
+	return Attr("charset", value)
+}
+
+// Makes the boolean "checked" attribute (input).
+func Checked() attr {
+	return BoolAttr("checked")
+}
+
+// Makes the "cite" attribute (blockquote, del, ins, q).
+func Cite(value string) attr {
+	return Attr("cite", value)
+}
+
+// Makes the "cols" attribute (textarea).
+func Cols(value string) attr {
+	return Attr("cols", value)
+}
+
+// Makes the "colspan" attribute (td, th).
+func ColSpan(value string) attr {
+	return Attr("colspan", value)
+}
+
+// Makes the "content" attribute (meta).
+func Content(value string) attr {
+	return Attr("content", value)
+}
+
+// Makes the boolean "controls" attribute (audio, video).
+func Controls() attr {
+	return BoolAttr("controls")
+}
+
+// Makes the "coords" attribute (area).
+func Coords(value string) attr {
+	return Attr("coords", value)
+}
+
+// Makes the "crossorigin" attribute (audio, img, link, script, video).
+func CrossOrigin(value string) attr {
+	return Attr("crossorigin", value)
+}
+
+// Makes the "data" attribute (object).
+func ObjectData(value string) attr {
+	return Attr("data", value)
+}
+
+// Makes the "datetime" attribute (del, ins, time).
+func DateTime(value string) attr {
+	return Attr("datetime", value)
+}
+
+// Makes the "decoding" attribute (img).
+func Decoding(value string) attr {
+	return Attr("decoding", value)
+}
+
+// Makes the boolean "default" attribute (track).
+func Default() attr {
+	return BoolAttr("default")
+}
+
+// Makes the boolean "defer" attribute (script).
+func Defer() attr {
+	return BoolAttr("defer")
+}
+
+// Makes the "dirname" attribute (input, textarea).
+func DirName(value string) attr {
+	return Attr("dirname", value)
+}
+
+// Makes the boolean "disabled" attribute (button, fieldset, input, optgroup, option, select, textarea).
+func Disabled() attr {
+	return BoolAttr("disabled")
+}
+
+// Makes the "download" attribute (a, area).
+func Download(value string) attr {
+	return Attr("download", value)
+}
+
+// Makes the "enctype" attribute (form).
+func EncType(value string) attr {
+	return Attr("enctype", value)
+}
+
+// Makes the "fetchpriority" attribute (img, link, script).
+func FetchPriority(value string) attr {
+	return Attr("fetchpriority", value)
+}
+
+// Makes the "for" attribute (label, output).
+func For(value string) attr {
+	return Attr("for", value)
+}
+
+// Makes the "form" attribute (button, fieldset, input, object, output, select, textarea).
+func Form(value string) attr {
+	return Attr("form", value)
+}
+
+// Makes the "formaction" attribute (button, input).
+func FormAction(value string) attr {
+	return Attr("formaction", value)
+}
+
+// Makes the "formenctype" attribute (button, input).
+func FormEncType(value string) attr {
+	return Attr("formenctype", value)
+}
+
+// Makes the "formmethod" attribute (button, input).
+func FormMethod(value string) attr {
+	return Attr("formmethod", value)
+}
+
+// Makes the boolean "formnovalidate" attribute (button, input).
+func FormNoValidate() attr {
+	return BoolAttr("formnovalidate")
+}
+
+// Makes the "formtarget" attribute (button, input).
+func FormTarget(value string) attr {
+	return Attr("formtarget", value)
+}
+
+// Makes the "headers" attribute (td, th).
+func Headers(value string) attr {
+	return Attr("headers", value)
+}
+
+// Makes the "height" attribute (canvas, embed, iframe, img, input, object, video).
+func Height(value string) attr {
+	return Attr("height", value)
+}
+
+// Makes the "high" attribute (meter).
+func High(value string) attr {
+	return Attr("high", value)
+}
+
+// Makes the "href" attribute (a, area, base, link).
+func Href(value string) attr {
+	return Attr("href", value)
+}
+
+// Makes the "hreflang" attribute (a, link).
+func HrefLang(value string) attr {
+	return Attr("hreflang", value)
+}
+
+// Makes the "http-equiv" attribute (meta).
+func HttpEquiv(value string) attr {
+	return Attr("http-equiv", value)
+}
+
+// Makes the "imagesizes" attribute (link).
+func ImageSizes(value string) attr {
+	return Attr("imagesizes", value)
+}
+
+// Makes the "imagesrcset" attribute (link).
+func ImageSrcSet(value string) attr {
+	return Attr("imagesrcset", value)
+}
+
+// Makes the "integrity" attribute (link, script).
+func Integrity(value string) attr {
+	return Attr("integrity", value)
+}
+
+// Makes the boolean "ismap" attribute (img).
+func IsMap() attr {
+	return BoolAttr("ismap")
+}
+
+// Makes the "kind" attribute (track).
+func Kind(value string) attr {
+	return Attr("kind", value)
+}
+
+// Makes the "label" attribute (optgroup, option, track).
+func Label(value string) attr {
+	return Attr("label", value)
+}
+
+// Makes the "list" attribute (input).
+func List(value string) attr {
+	return Attr("list", value)
+}
+
+// Makes the "loading" attribute (iframe, img).
+func Loading(value string) attr {
+	return Attr("loading", value)
+}
+
+// Makes the boolean "loop" attribute (audio, video).
+func Loop() attr {
+	return BoolAttr("loop")
+}
+
+// Makes the "low" attribute (meter).
+func Low(value string) attr {
+	return Attr("low", value)
+}
+
+// Makes the "max" attribute (input, meter, progress).
+func Max(value string) attr {
+	return Attr("max", value)
+}
+
+// Makes the "maxlength" attribute (input, textarea).
+func MaxLength(value string) attr {
+	return Attr("maxlength", value)
+}
+
+// Makes the "media" attribute (link, meta, source, style).
+func Media(value string) attr {
+	return Attr("media", value)
+}
+
+// Makes the "method" attribute (form).
+func Method(value string) attr {
+	return Attr("method", value)
+}
+
+// Makes the "min" attribute (input, meter).
+func Min(value string) attr {
+	return Attr("min", value)
+}
+
+// Makes the "minlength" attribute (input, textarea).
+func MinLength(value string) attr {
+	return Attr("minlength", value)
+}
+
+// Makes the boolean "multiple" attribute (input, select).
+func Multiple() attr {
+	return BoolAttr("multiple")
+}
+
+// Makes the boolean "muted" attribute (audio, video).
+func Muted() attr {
+	return BoolAttr("muted")
+}
+
+// Makes the "name" attribute (button, details, fieldset, form, iframe, input, map, meta, object, output, select, slot, textarea).
+func Name(value string) attr {
+	return Attr("name", value)
+}
+
+// Makes the boolean "nomodule" attribute (script).
+func NoModule() attr {
+	return BoolAttr("nomodule")
+}
+
+// Makes the boolean "novalidate" attribute (form).
+func NoValidate() attr {
+	return BoolAttr("novalidate")
+}
+
+// Makes the boolean "open" attribute (details, dialog).
+func Open() attr {
+	return BoolAttr("open")
+}
+
+// Makes the "optimum" attribute (meter).
+func Optimum(value string) attr {
+	return Attr("optimum", value)
+}
+
+// Makes the "pattern" attribute (input).
+func Pattern(value string) attr {
+	return Attr("pattern", value)
+}
+
+// Makes the "ping" attribute (a, area).
+func Ping(value string) attr {
+	return Attr("ping", value)
+}
+
+// Makes the "placeholder" attribute (input, textarea).
+func Placeholder(value string) attr {
+	return Attr("placeholder", value)
+}
+
+// Makes the boolean "playsinline" attribute (video).
+func PlaysInline() attr {
+	return BoolAttr("playsinline")
+}
+
+// Makes the "popovertarget" attribute (button, input).
+func PopoverTarget(value string) attr {
+	return Attr("popovertarget", value)
+}
+
+// Makes the "popovertargetaction" attribute (button, input).
+func PopoverTargetAction(value string) attr {
+	return Attr("popovertargetaction", value)
+}
+
+// Makes the "poster" attribute (video).
+func Poster(value string) attr {
+	return Attr("poster", value)
+}
+
+// Makes the "preload" attribute (audio, video).
+func Preload(value string) attr {
+	return Attr("preload", value)
+}
+
+// Makes the boolean "readonly" attribute (input, textarea).
+func ReadOnly() attr {
+	return BoolAttr("readonly")
+}
+
+// Makes the "referrerpolicy" attribute (a, area, iframe, img, link, script).
+func ReferrerPolicy(value string) attr {
+	return Attr("referrerpolicy", value)
+}
+
+// Makes the "rel" attribute (a, area, form, link).
+func Rel(value string) attr {
+	return Attr("rel", value)
+}
+
+// Makes the boolean "required" attribute (input, select, textarea).
+func Required() attr {
+	return BoolAttr("required")
+}
+
+// Makes the boolean "reversed" attribute (ol).
+func Reversed() attr {
+	return BoolAttr("reversed")
+}
+
+// Makes the "rows" attribute (textarea).
+func Rows(value string) attr {
+	return Attr("rows", value)
+}
+
+// Makes the "rowspan" attribute (td, th).
+func RowSpan(value string) attr {
+	return Attr("rowspan", value)
+}
+
+// Makes the "sandbox" attribute (iframe).
+func Sandbox(value string) attr {
+	return Attr("sandbox", value)
+}
+
+// Makes the "scope" attribute (th).
+func Scope(value string) attr {
+	return Attr("scope", value)
+}
+
+// Makes the boolean "selected" attribute (option).
+func Selected() attr {
+	return BoolAttr("selected")
+}
+
+// Makes the "shape" attribute (area).
+func Shape(value string) attr {
+	return Attr("shape", value)
+}
+
+// Makes the "size" attribute (input, select).
+func Size(value string) attr {
+	return Attr("size", value)
+}
+
+// Makes the "sizes" attribute (img, link, source).
+func Sizes(value string) attr {
+	return Attr("sizes", value)
+}
+
+// Makes the "span" attribute (col, colgroup).
+func Span(value string) attr {
+	return Attr("span", value)
+}
+
+// Makes the "src" attribute (audio, embed, iframe, img, input, script, source, track, video).
+func Src(value string) attr {
+	return Attr("src", value)
+}
+
+// Makes the "srcdoc" attribute (iframe).
+func SrcDoc(value string) attr {
+	return Attr("srcdoc", value)
+}
+
+// Makes the "srclang" attribute (track).
+func SrcLang(value string) attr {
+	return Attr("srclang", value)
+}
+
+// Makes the "srcset" attribute (img, source).
+func SrcSet(value string) attr {
+	return Attr("srcset", value)
+}
+
+// Makes the "start" attribute (ol).
+func Start(value string) attr {
+	return Attr("start", value)
+}
+
+// Makes the "step" attribute (input).
+func Step(value string) attr {
+	return Attr("step", value)
+}
+
+// Makes the "target" attribute (a, area, base, form).
+func Target(value string) attr {
+	return Attr("target", value)
+}
+
+// Makes the "type" attribute (a, button, embed, input, link, object, ol, script, source, style).
+func Type(value string) attr {
+	return Attr("type", value)
+}
+
+// Makes the "usemap" attribute (img).
+func UseMap(value string) attr {
+	return Attr("usemap", value)
+}
+
+// Makes the "value" attribute (button, data, input, li, meter, option, output, progress).
+func Value(value string) attr {
+	return Attr("value", value)
+}
+
+// Makes the "width" attribute (canvas, embed, iframe, img, input, object, video).
+func Width(value string) attr {
+	return Attr("width", value)
+}
+
+// Makes the "wrap" attribute (textarea).
+func Wrap(value string) attr {
+	return Attr("wrap", value)
+}
+
+// Makes the "clip-path" attribute (SVG elements).
+func ClipPath(value string) attr {
+	return Attr("clip-path", value)
+}
+
+// Makes the "cx" attribute (circle, ellipse, radialGradient).
+func Cx(value string) attr {
+	return Attr("cx", value)
+}
+
+// Makes the "cy" attribute (circle, ellipse, radialGradient).
+func Cy(value string) attr {
+	return Attr("cy", value)
+}
+
+// Makes the "d" attribute (path).
+func D(value string) attr {
+	return Attr("d", value)
+}
+
+// Makes the "dx" attribute (text, tspan).
+func Dx(value string) attr {
+	return Attr("dx", value)
+}
+
+// Makes the "dy" attribute (text, tspan).
+func Dy(value string) attr {
+	return Attr("dy", value)
+}
+
+// Makes the "fill" attribute (SVG elements).
+func Fill(value string) attr {
+	return Attr("fill", value)
+}
+
+// Makes the "fill-opacity" attribute (SVG elements).
+func FillOpacity(value string) attr {
+	return Attr("fill-opacity", value)
+}
+
+// Makes the "font-family" attribute (SVG elements).
+func FontFamily(value string) attr {
+	return Attr("font-family", value)
+}
+
+// Makes the "font-size" attribute (SVG elements).
+func FontSize(value string) attr {
+	return Attr("font-size", value)
+}
+
+// Makes the "gradientTransform" attribute (linearGradient, radialGradient).
+func GradientTransform(value string) attr {
+	return Attr("gradientTransform", value)
+}
+
+// Makes the "gradientUnits" attribute (linearGradient, radialGradient).
+func GradientUnits(value string) attr {
+	return Attr("gradientUnits", value)
+}
+
+// Makes the "marker-end" attribute (line, path, polyline, polygon).
+func MarkerEnd(value string) attr {
+	return Attr("marker-end", value)
+}
+
+// Makes the "marker-start" attribute (line, path, polyline, polygon).
+func MarkerStart(value string) attr {
+	return Attr("marker-start", value)
+}
+
+// Makes the "offset" attribute (stop).
+func Offset(value string) attr {
+	return Attr("offset", value)
+}
+
+// Makes the "opacity" attribute (SVG elements).
+func Opacity(value string) attr {
+	return Attr("opacity", value)
+}
+
+// Makes the "points" attribute (polygon, polyline).
+func Points(value string) attr {
+	return Attr("points", value)
+}
+
+// Makes the "preserveAspectRatio" attribute (svg, image, marker, pattern, symbol).
+func PreserveAspectRatio(value string) attr {
+	return Attr("preserveAspectRatio", value)
+}
+
+// Makes the "r" attribute (circle, radialGradient).
+func R(value string) attr {
+	return Attr("r", value)
+}
+
+// Makes the "rx" attribute (ellipse, rect).
+func Rx(value string) attr {
+	return Attr("rx", value)
+}
+
+// Makes the "ry" attribute (ellipse, rect).
+func Ry(value string) attr {
+	return Attr("ry", value)
+}
+
+// Makes the "stop-color" attribute (stop).
+func StopColor(value string) attr {
+	return Attr("stop-color", value)
+}
+
+// Makes the "stroke" attribute (SVG elements).
+func Stroke(value string) attr {
+	return Attr("stroke", value)
+}
+
+// Makes the "stroke-dasharray" attribute (SVG elements).
+func StrokeDashArray(value string) attr {
+	return Attr("stroke-dasharray", value)
+}
+
+// Makes the "stroke-linecap" attribute (SVG elements).
+func StrokeLineCap(value string) attr {
+	return Attr("stroke-linecap", value)
+}
+
+// Makes the "stroke-linejoin" attribute (SVG elements).
+func StrokeLineJoin(value string) attr {
+	return Attr("stroke-linejoin", value)
+}
+
+// Makes the "stroke-opacity" attribute (SVG elements).
+func StrokeOpacity(value string) attr {
+	return Attr("stroke-opacity", value)
+}
+
+// Makes the "stroke-width" attribute (SVG elements).
+func StrokeWidth(value string) attr {
+	return Attr("stroke-width", value)
+}
+
+// Makes the "text-anchor" attribute (text, tspan).
+func TextAnchor(value string) attr {
+	return Attr("text-anchor", value)
+}
+
+// Makes the "transform" attribute (SVG elements).
+func Transform(value string) attr {
+	return Attr("transform", value)
+}
+
+// Makes the "viewBox" attribute (svg, marker, pattern, symbol).
+func ViewBox(value string) attr {
+	return Attr("viewBox", value)
+}
+
+// Makes the "x" attribute (SVG elements).
+func X(value string) attr {
+	return Attr("x", value)
+}
+
+// Makes the "x1" attribute (line, linearGradient).
+func X1(value string) attr {
+	return Attr("x1", value)
+}
+
+// Makes the "x2" attribute (line, linearGradient).
+func X2(value string) attr {
+	return Attr("x2", value)
+}
+
+// Makes the "y" attribute (SVG elements).
+func Y(value string) attr {
+	return Attr("y", value)
+}
+
+// Makes the "y1" attribute (line, linearGradient).
+func Y1(value string) attr {
+	return Attr("y1", value)
+}
+
+// Makes the "y2" attribute (line, linearGradient).
+func Y2(value string) attr {
+	return Attr("y2", value)
+}
+
+// Attributes whose value is code run on an event.
+var eventHandlerAttrs = map[string]bool{
+	"onabort":              true,
+	"onanimationcancel":    true,
+	"onanimationend":       true,
+	"onanimationiteration": true,
+	"onanimationstart":     true,
+	"onauxclick":           true,
+	"onbeforeinput":        true,
+	"onblur":               true,
+	"oncancel":             true,
+	"onchange":             true,
+	"onclick":              true,
+	"onclose":              true,
+	"oncontextmenu":        true,
+	"oncopy":               true,
+	"oncut":                true,
+	"ondblclick":           true,
+	"ondrag":               true,
+	"ondragend":            true,
+	"ondragenter":          true,
+	"ondragleave":          true,
+	"ondragover":           true,
+	"ondragstart":          true,
+	"ondrop":               true,
+	"onerror":              true,
+	"onfocus":              true,
+	"ongotpointercapture":  true,
+	"oninput":              true,
+	"oninvalid":            true,
+	"onkeydown":            true,
+	"onkeypress":           true,
+	"onkeyup":              true,
+	"onload":               true,
+	"onlostpointercapture": true,
+	"onmousedown":          true,
+	"onmouseenter":         true,
+	"onmouseleave":         true,
+	"onmousemove":          true,
+	"onmouseout":           true,
+	"onmouseover":          true,
+	"onmouseup":            true,
+	"onpaste":              true,
+	"onpointercancel":      true,
+	"onpointerdown":        true,
+	"onpointerenter":       true,
+	"onpointerleave":       true,
+	"onpointermove":        true,
+	"onpointerout":         true,
+	"onpointerover":        true,
+	"onpointerup":          true,
+	"onreset":              true,
+	"onresize":             true,
+	"onscroll":             true,
+	"onselect":             true,
+	"onsubmit":             true,
+	"ontoggle":             true,
+	"ontouchcancel":        true,
+	"ontouchend":           true,
+	"ontouchmove":          true,
+	"ontouchstart":         true,
+	"ontransitioncancel":   true,
+	"ontransitionend":      true,
+	"ontransitionrun":      true,
+	"ontransitionstart":    true,
+	"onwheel":              true,
+	"onbeforeunload":       true,
+	"onhashchange":         true,
+	"onpagehide":           true,
+	"onpageshow":           true,
+	"onpopstate":           true,
+	"onunload":             true,
+}
+
+// Functions for attributes, by name.
+var attrInfos = map[string]AttrInfo{
+	"accesskey":            {Function: "AccessKey"},
+	"autocapitalize":       {Function: "AutoCapitalize"},
+	"autofocus":            {Function: "AutoFocus", Boolean: true},
+	"class":                {Function: "Class"},
+	"contenteditable":      {Function: "ContentEditable"},
+	"dir":                  {Function: "Dir"},
+	"draggable":            {Function: "Draggable"},
+	"enterkeyhint":         {Function: "EnterKeyHint"},
+	"hidden":               {Function: "Hidden", Boolean: true},
+	"id":                   {Function: "Id"},
+	"inert":                {Function: "Inert", Boolean: true},
+	"inputmode":            {Function: "InputMode"},
+	"is":                   {Function: "Is"},
+	"itemid":               {Function: "ItemId"},
+	"itemprop":             {Function: "ItemProp"},
+	"itemref":              {Function: "ItemRef"},
+	"itemscope":            {Function: "ItemScope", Boolean: true},
+	"itemtype":             {Function: "ItemType"},
+	"lang":                 {Function: "Lang"},
+	"nonce":                {Function: "Nonce"},
+	"popover":              {Function: "Popover"},
+	"role":                 {Function: "Role"},
+	"slot":                 {Function: "Slot"},
+	"spellcheck":           {Function: "SpellCheck"},
+	"style":                {Function: "Style"},
+	"tabindex":             {Function: "TabIndex"},
+	"title":                {Function: "Title"},
+	"translate":            {Function: "Translate"},
+	"onabort":              {Function: "OnAbort"},
+	"onanimationcancel":    {Function: "OnAnimationCancel"},
+	"onanimationend":       {Function: "OnAnimationEnd"},
+	"onanimationiteration": {Function: "OnAnimationIteration"},
+	"onanimationstart":     {Function: "OnAnimationStart"},
+	"onauxclick":           {Function: "OnAuxClick"},
+	"onbeforeinput":        {Function: "OnBeforeInput"},
+	"onblur":               {Function: "OnBlur"},
+	"oncancel":             {Function: "OnCancel"},
+	"onchange":             {Function: "OnChange"},
+	"onclick":              {Function: "OnClick"},
+	"onclose":              {Function: "OnClose"},
+	"oncontextmenu":        {Function: "OnContextMenu"},
+	"oncopy":               {Function: "OnCopy"},
+	"oncut":                {Function: "OnCut"},
+	"ondblclick":           {Function: "OnDblClick"},
+	"ondrag":               {Function: "OnDrag"},
+	"ondragend":            {Function: "OnDragEnd"},
+	"ondragenter":          {Function: "OnDragEnter"},
+	"ondragleave":          {Function: "OnDragLeave"},
+	"ondragover":           {Function: "OnDragOver"},
+	"ondragstart":          {Function: "OnDragStart"},
+	"ondrop":               {Function: "OnDrop"},
+	"onerror":              {Function: "OnError"},
+	"onfocus":              {Function: "OnFocus"},
+	"ongotpointercapture":  {Function: "OnGotPointerCapture"},
+	"oninput":              {Function: "OnInput"},
+	"oninvalid":            {Function: "OnInvalid"},
+	"onkeydown":            {Function: "OnKeyDown"},
+	"onkeypress":           {Function: "OnKeyPress"},
+	"onkeyup":              {Function: "OnKeyUp"},
+	"onload":               {Function: "OnLoad"},
+	"onlostpointercapture": {Function: "OnLostPointerCapture"},
+	"onmousedown":          {Function: "OnMouseDown"},
+	"onmouseenter":         {Function: "OnMouseEnter"},
+	"onmouseleave":         {Function: "OnMouseLeave"},
+	"onmousemove":          {Function: "OnMouseMove"},
+	"onmouseout":           {Function: "OnMouseOut"},
+	"onmouseover":          {Function: "OnMouseOver"},
+	"onmouseup":            {Function: "OnMouseUp"},
+	"onpaste":              {Function: "OnPaste"},
+	"onpointercancel":      {Function: "OnPointerCancel"},
+	"onpointerdown":        {Function: "OnPointerDown"},
+	"onpointerenter":       {Function: "OnPointerEnter"},
+	"onpointerleave":       {Function: "OnPointerLeave"},
+	"onpointermove":        {Function: "OnPointerMove"},
+	"onpointerout":         {Function: "OnPointerOut"},
+	"onpointerover":        {Function: "OnPointerOver"},
+	"onpointerup":          {Function: "OnPointerUp"},
+	"onreset":              {Function: "OnReset"},
+	"onresize":             {Function: "OnResize"},
+	"onscroll":             {Function: "OnScroll"},
+	"onselect":             {Function: "OnSelect"},
+	"onsubmit":             {Function: "OnSubmit"},
+	"ontoggle":             {Function: "OnToggle"},
+	"ontouchcancel":        {Function: "OnTouchCancel"},
+	"ontouchend":           {Function: "OnTouchEnd"},
+	"ontouchmove":          {Function: "OnTouchMove"},
+	"ontouchstart":         {Function: "OnTouchStart"},
+	"ontransitioncancel":   {Function: "OnTransitionCancel"},
+	"ontransitionend":      {Function: "OnTransitionEnd"},
+	"ontransitionrun":      {Function: "OnTransitionRun"},
+	"ontransitionstart":    {Function: "OnTransitionStart"},
+	"onwheel":              {Function: "OnWheel"},
+	"onbeforeunload":       {Function: "OnBeforeUnload"},
+	"onhashchange":         {Function: "OnHashChange"},
+	"onpagehide":           {Function: "OnPageHide"},
+	"onpageshow":           {Function: "OnPageShow"},
+	"onpopstate":           {Function: "OnPopState"},
+	"onunload":             {Function: "OnUnload"},
+	"abbr":                 {Function: "Abbr"},
+	"accept":               {Function: "Accept"},
+	"accept-charset":       {Function: "AcceptCharset"},
+	"action":               {Function: "Action"},
+	"allow":                {Function: "Allow"},
+	"allowfullscreen":      {Function: "AllowFullscreen", Boolean: true},
+	"alt":                  {Function: "Alt"},
+	"as":                   {Function: "As"},
+	"async":                {Function: "Async", Boolean: true},
+	"autocomplete":         {Function: "AutoComplete"},
+	"autoplay":             {Function: "AutoPlay", Boolean: true},
+	"blocking":             {Function: "Blocking"},
+	"charset":              {Function: "Charset"},
+	"checked":              {Function: "Checked", Boolean: true},
+	"cite":                 {Function: "Cite"},
+	"cols":                 {Function: "Cols"},
+	"colspan":              {Function: "ColSpan"},
+	"content":              {Function: "Content"},
+	"controls":             {Function: "Controls", Boolean: true},
+	"coords":               {Function: "Coords"},
+	"crossorigin":          {Function: "CrossOrigin"},
+	"data":                 {Function: "ObjectData"},
+	"datetime":             {Function: "DateTime"},
+	"decoding":             {Function: "Decoding"},
+	"default":              {Function: "Default", Boolean: true},
+	"defer":                {Function: "Defer", Boolean: true},
+	"dirname":              {Function: "DirName"},
+	"disabled":             {Function: "Disabled", Boolean: true},
+	"download":             {Function: "Download"},
+	"enctype":              {Function: "EncType"},
+	"fetchpriority":        {Function: "FetchPriority"},
+	"for":                  {Function: "For"},
+	"form":                 {Function: "Form"},
+	"formaction":           {Function: "FormAction"},
+	"formenctype":          {Function: "FormEncType"},
+	"formmethod":           {Function: "FormMethod"},
+	"formnovalidate":       {Function: "FormNoValidate", Boolean: true},
+	"formtarget":           {Function: "FormTarget"},
+	"headers":              {Function: "Headers"},
+	"height":               {Function: "Height"},
+	"high":                 {Function: "High"},
+	"href":                 {Function: "Href"},
+	"hreflang":             {Function: "HrefLang"},
+	"http-equiv":           {Function: "HttpEquiv"},
+	"imagesizes":           {Function: "ImageSizes"},
+	"imagesrcset":          {Function: "ImageSrcSet"},
+	"integrity":            {Function: "Integrity"},
+	"ismap":                {Function: "IsMap", Boolean: true},
+	"kind":                 {Function: "Kind"},
+	"label":                {Function: "Label"},
+	"list":                 {Function: "List"},
+	"loading":              {Function: "Loading"},
+	"loop":                 {Function: "Loop", Boolean: true},
+	"low":                  {Function: "Low"},
+	"max":                  {Function: "Max"},
+	"maxlength":            {Function: "MaxLength"},
+	"media":                {Function: "Media"},
+	"method":               {Function: "Method"},
+	"min":                  {Function: "Min"},
+	"minlength":            {Function: "MinLength"},
+	"multiple":             {Function: "Multiple", Boolean: true},
+	"muted":                {Function: "Muted", Boolean: true},
+	"name":                 {Function: "Name"},
+	"nomodule":             {Function: "NoModule", Boolean: true},
+	"novalidate":           {Function: "NoValidate", Boolean: true},
+	"open":                 {Function: "Open", Boolean: true},
+	"optimum":              {Function: "Optimum"},
+	"pattern":              {Function: "Pattern"},
+	"ping":                 {Function: "Ping"},
+	"placeholder":          {Function: "Placeholder"},
+	"playsinline":          {Function: "PlaysInline", Boolean: true},
+	"popovertarget":        {Function: "PopoverTarget"},
+	"popovertargetaction":  {Function: "PopoverTargetAction"},
+	"poster":               {Function: "Poster"},
+	"preload":              {Function: "Preload"},
+	"readonly":             {Function: "ReadOnly", Boolean: true},
+	"referrerpolicy":       {Function: "ReferrerPolicy"},
+	"rel":                  {Function: "Rel"},
+	"required":             {Function: "Required", Boolean: true},
+	"reversed":             {Function: "Reversed", Boolean: true},
+	"rows":                 {Function: "Rows"},
+	"rowspan":              {Function: "RowSpan"},
+	"sandbox":              {Function: "Sandbox"},
+	"scope":                {Function: "Scope"},
+	"selected":             {Function: "Selected", Boolean: true},
+	"shape":                {Function: "Shape"},
+	"size":                 {Function: "Size"},
+	"sizes":                {Function: "Sizes"},
+	"span":                 {Function: "Span"},
+	"src":                  {Function: "Src"},
+	"srcdoc":               {Function: "SrcDoc"},
+	"srclang":              {Function: "SrcLang"},
+	"srcset":               {Function: "SrcSet"},
+	"start":                {Function: "Start"},
+	"step":                 {Function: "Step"},
+	"target":               {Function: "Target"},
+	"type":                 {Function: "Type"},
+	"usemap":               {Function: "UseMap"},
+	"value":                {Function: "Value"},
+	"width":                {Function: "Width"},
+	"wrap":                 {Function: "Wrap"},
+	"clip-path":            {Function: "ClipPath"},
+	"cx":                   {Function: "Cx"},
+	"cy":                   {Function: "Cy"},
+	"d":                    {Function: "D"},
+	"dx":                   {Function: "Dx"},
+	"dy":                   {Function: "Dy"},
+	"fill":                 {Function: "Fill"},
+	"fill-opacity":         {Function: "FillOpacity"},
+	"font-family":          {Function: "FontFamily"},
+	"font-size":            {Function: "FontSize"},
+	"gradientTransform":    {Function: "GradientTransform"},
+	"gradientUnits":        {Function: "GradientUnits"},
+	"marker-end":           {Function: "MarkerEnd"},
+	"marker-start":         {Function: "MarkerStart"},
+	"offset":               {Function: "Offset"},
+	"opacity":              {Function: "Opacity"},
+	"points":               {Function: "Points"},
+	"preserveAspectRatio":  {Function: "PreserveAspectRatio"},
+	"r":                    {Function: "R"},
+	"rx":                   {Function: "Rx"},
+	"ry":                   {Function: "Ry"},
+	"stop-color":           {Function: "StopColor"},
+	"stroke":               {Function: "Stroke"},
+	"stroke-dasharray":     {Function: "StrokeDashArray"},
+	"stroke-linecap":       {Function: "StrokeLineCap"},
+	"stroke-linejoin":      {Function: "StrokeLineJoin"},
+	"stroke-opacity":       {Function: "StrokeOpacity"},
+	"stroke-width":         {Function: "StrokeWidth"},
+	"text-anchor":          {Function: "TextAnchor"},
+	"transform":            {Function: "Transform"},
+	"viewBox":              {Function: "ViewBox"},
+	"x":                    {Function: "X"},
+	"x1":                   {Function: "X1"},
+	"x2":                   {Function: "X2"},
+	"y":                    {Function: "Y"},
+	"y1":                   {Function: "Y1"},
+	"y2":                   {Function: "Y2"},
+}
@@ -0,0 +1,176 @@
+package snake
+
+import (
+	"bytes"
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Stands in for the response's nonce in cached markup, since each response
+// has its own.
+const cacheNoncePlaceholder = "zSnakeNoncez"
+
+// Storage for fragments rendered by Cache(). It must be safe for concurrent
+// use, since it's shared by all requests.
+type FragmentStore interface {
+	// Return the fragment for the key, if it's present and hasn't expired.
+	Get(key string) ([]byte, bool)
+
+	// Store the fragment for the key. It expires after ttl, or never if
+	// ttl is zero.
+	Set(key string, data []byte, ttl time.Duration)
+
+	// Remove the fragment for the key, if it's present.
+	Delete(key string)
+}
+
+// A cached fragment in an LruStore.
+type lruEntry struct {
+	key     string
+	data    []byte
+	expires time.Time
+}
+
+// An in-memory FragmentStore that keeps at most a fixed number of fragments,
+// dropping the least recently used ones first.
+type LruStore struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+
+	// For tests.
+	now func() time.Time
+}
+
+// Make a store that holds at most capacity fragments.
+func NewLruStore(capacity int) *LruStore {
+	return &LruStore{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+func (s *LruStore) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := e.Value.(*lruEntry)
+	if !entry.expires.IsZero() && !s.now().Before(entry.expires) {
+		s.remove(e)
+		return nil, false
+	}
+
+	s.order.MoveToFront(e)
+	return entry.data, true
+}
+
+func (s *LruStore) Set(key string, data []byte, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = s.now().Add(ttl)
+	}
+
+	if e, ok := s.entries[key]; ok {
+		entry := e.Value.(*lruEntry)
+		entry.data = data
+		entry.expires = expires
+		s.order.MoveToFront(e)
+		return
+	}
+
+	s.entries[key] = s.order.PushFront(&lruEntry{key, data, expires})
+	for s.order.Len() > s.capacity {
+		s.remove(s.order.Back())
+	}
+}
+
+func (s *LruStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok {
+		s.remove(e)
+	}
+}
+
+// Remove all fragments.
+func (s *LruStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = make(map[string]*list.Element)
+	s.order.Init()
+}
+
+// Return the number of fragments stored, including expired ones not yet
+// dropped.
+func (s *LruStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.order.Len()
+}
+
+// Must be called with the lock held.
+func (s *LruStore) remove(e *list.Element) {
+	delete(s.entries, e.Value.(*lruEntry).key)
+	s.order.Remove(e)
+}
+
+// Render the component once and keep its markup in the store under the key
+// for ttl (forever if zero). Later calls with the same key write the stored
+// markup without calling the component. Remove the key from the store to
+// render it again:
+//
+//	h.Cache(fragments, "menu:"+lang, time.Hour, ComponentFunc(func(h *HtmlResponse) {
+//		renderCategoryMenu(h, db, lang)
+//	}))
+//
+// The key must include anything the markup depends on, such as the language.
+// The component must close every element it opens, and markup with tag errors
+// isn't stored. Each response's nonce is put into the cached markup when it's
+// written. The markup is rendered as if it were at the top of the page, so
+// with OutputPretty its indentation starts at the left margin, and layouts
+// don't hoist its StyleLink() and ScriptLink() calls. When building a tree,
+// linting, or inside <script> or <style>, the component is always rendered.
+func (h *HtmlResponse) Cache(store FragmentStore, key string, ttl time.Duration, c Component) *HtmlResponse {
+	if h.tree != nil || h.lint != nil || h.isOpen("script") || h.isOpen("style") {
+		return h.Render(c)
+	}
+
+	data, ok := store.Get(key)
+	if !ok {
+		var buf bytes.Buffer
+		sub := h.sub(&buf)
+		sub.nonce = cacheNoncePlaceholder
+		sub.Render(c)
+		err := sub.Close()
+		h.tagErrors = append(h.tagErrors, sub.tagErrors...)
+
+		data = buf.Bytes()
+		if err == nil {
+			store.Set(key, data, ttl)
+		}
+	}
+
+	if h.nonce != "" {
+		data = bytes.ReplaceAll(data, []byte(cacheNoncePlaceholder), []byte(h.nonce))
+	} else {
+		data = bytes.ReplaceAll(data, []byte(` nonce="`+cacheNoncePlaceholder+`"`), nil)
+	}
+	h.WriteStr(string(data))
+
+	return h
+}
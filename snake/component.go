@@ -0,0 +1,85 @@
+package snake
+
+// A reusable piece of a page, such as a header, navbar, card, or pager.
+//
+// A component that takes children (like a card wrapping arbitrary content)
+// should have fields of type Component and render them in place:
+//
+//	type Card struct {
+//		Title string
+//		Body  Component
+//	}
+//
+//	func (c Card) Render(h *HtmlResponse) {
+//		h.Div(Class("card")).
+//			H2().Content(c.Title).
+//			Div(Class("card-body")).Render(c.Body).Div_().
+//			Div_()
+//	}
+type Component interface {
+	Render(h *HtmlResponse)
+}
+
+// Adapts an ordinary function to the Component interface.
+type ComponentFunc func(h *HtmlResponse)
+
+func (f ComponentFunc) Render(h *HtmlResponse) {
+	f(h)
+}
+
+// Render the components in order. Nil components are skipped.
+func (h *HtmlResponse) Render(components ...Component) *HtmlResponse {
+	for _, c := range components {
+		if c != nil {
+			c.Render(h)
+		}
+	}
+
+	return h
+}
+
+// Render the component only if cond is true.
+func (h *HtmlResponse) RenderIf(cond bool, c Component) *HtmlResponse {
+	if cond {
+		h.Render(c)
+	}
+
+	return h
+}
+
+// Render then if cond is true, otherwise otherwise.
+func (h *HtmlResponse) RenderIfElse(cond bool, then, otherwise Component) *HtmlResponse {
+	if cond {
+		return h.Render(then)
+	}
+
+	return h.Render(otherwise)
+}
+
+// Combine several components into one, for passing as children.
+func Group(components ...Component) Component {
+	return ComponentFunc(func(h *HtmlResponse) {
+		h.Render(components...)
+	})
+}
+
+// A component that writes escaped text.
+func TextComponent(s string) Component {
+	return ComponentFunc(func(h *HtmlResponse) {
+		h.Text(s)
+	})
+}
+
+// Make one component per item and combine them, for rendering a list of
+// cards, rows, etc.:
+//
+//	h.Ul().Render(Each(users, func(u User) Component {
+//		return UserItem{u}
+//	})).Ul_()
+func Each[T any](items []T, f func(item T) Component) Component {
+	return ComponentFunc(func(h *HtmlResponse) {
+		for _, item := range items {
+			h.Render(f(item))
+		}
+	})
+}
@@ -0,0 +1,337 @@
+package snake
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/lkesteloot/goutil/dbutil"
+	"github.com/lkesteloot/goutil/sortutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Converts a column value to the text shown in its cell.
+type CellFormatter func(value interface{}) string
+
+// Formatters that can be named in struct tags without registering them.
+var builtinFormatters = map[string]CellFormatter{
+	"date": func(value interface{}) string {
+		return formatTime(value, "2006-01-02")
+	},
+	"datetime": func(value interface{}) string {
+		return formatTime(value, "2006-01-02 15:04")
+	},
+	"upper": func(value interface{}) string {
+		return strings.ToUpper(fmt.Sprint(value))
+	},
+}
+
+func formatTime(value interface{}, layout string) string {
+	t, ok := value.(time.Time)
+	if !ok || t.IsZero() {
+		return ""
+	}
+
+	return t.Format(layout)
+}
+
+// One cell of a data table.
+type dataCell struct {
+	text string
+
+	// For sorting. Numeric cells sort by number, others by text with
+	// embedded numbers compared numerically. Null cells sort first.
+	numeric bool
+	number  float64
+	isNull  bool
+}
+
+// One column of a data table.
+type dataColumn struct {
+	// Key used in the sort query parameter.
+	key    string
+	header string
+}
+
+// Renders rows of data as a table whose columns can be sorted by clicking
+// their headers. Build one from a slice of structs with NewDataTable() or
+// from a query with NewDataTableFromRows(), call SortFrom() with the request,
+// and render it like any Component.
+//
+// Struct fields become columns in order. The "snake" struct tag sets the
+// column header and optionally the name of a formatter, and "-" skips the
+// field:
+//
+//	type Order struct {
+//		Id       dbutil.IdField `snake:"-"`
+//		Customer string         `snake:"Customer"`
+//		Total    dbutil.Money   `snake:"Total"`
+//		Placed   time.Time      `snake:"Placed on,date"`
+//	}
+//
+// dbutil.Money, Percent, and Boolean columns are shown with their
+// ToTextField() output and sorted by value.
+type DataTable struct {
+	columns []dataColumn
+	rows    [][]dataCell
+
+	// Current sort, from the request.
+	url       *url.URL
+	sortIndex int
+	sortDesc  bool
+}
+
+// Make a table from a slice of structs or pointers to structs. Extra
+// formatters, by the name used in struct tags, may be given.
+func NewDataTable(rows interface{}, formatters map[string]CellFormatter) *DataTable {
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("NewDataTable needs a slice, got %T", rows))
+	}
+
+	elemType := v.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("NewDataTable needs a slice of structs, got %T", rows))
+	}
+
+	t := &DataTable{sortIndex: -1}
+
+	// Pick the columns.
+	var fieldIndices []int
+	var fieldFormatters []CellFormatter
+	for i := 0; i < elemType.NumField(); i++ {
+		field := elemType.Field(i)
+		tag := field.Tag.Get("snake")
+		if field.PkgPath != "" || tag == "-" {
+			continue
+		}
+
+		header, formatName, _ := strings.Cut(tag, ",")
+		if header == "" {
+			header = field.Name
+		}
+
+		var formatter CellFormatter
+		if formatName != "" {
+			formatter = formatters[formatName]
+			if formatter == nil {
+				formatter = builtinFormatters[formatName]
+			}
+			if formatter == nil {
+				panic(fmt.Sprintf("Unknown formatter %q for field %s", formatName, field.Name))
+			}
+		}
+
+		t.columns = append(t.columns, dataColumn{
+			key:    strings.ToLower(field.Name),
+			header: header,
+		})
+		fieldIndices = append(fieldIndices, i)
+		fieldFormatters = append(fieldFormatters, formatter)
+	}
+
+	// Convert the rows.
+	for i := 0; i < v.Len(); i++ {
+		row := v.Index(i)
+		if row.Kind() == reflect.Ptr {
+			row = row.Elem()
+		}
+
+		cells := make([]dataCell, len(fieldIndices))
+		for j, fieldIndex := range fieldIndices {
+			value := row.Field(fieldIndex).Interface()
+			cells[j] = makeCell(value)
+			if fieldFormatters[j] != nil {
+				cells[j].text = fieldFormatters[j](value)
+			}
+		}
+		t.rows = append(t.rows, cells)
+	}
+
+	return t
+}
+
+// Make a table from the remaining rows of a query, using the column names
+// as headers. Closes the rows.
+func NewDataTableFromRows(rows *sql.Rows) (*DataTable, error) {
+	defer rows.Close()
+
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &DataTable{sortIndex: -1}
+	for _, name := range names {
+		t.columns = append(t.columns, dataColumn{
+			key:    strings.ToLower(name),
+			header: name,
+		})
+	}
+
+	values := make([]sql.NullString, len(names))
+	pointers := make([]interface{}, len(names))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	for rows.Next() {
+		err = rows.Scan(pointers...)
+		if err != nil {
+			return nil, err
+		}
+
+		cells := make([]dataCell, len(names))
+		for i, value := range values {
+			cells[i] = dataCell{text: value.String, isNull: !value.Valid}
+		}
+		t.rows = append(t.rows, cells)
+	}
+
+	return t, rows.Err()
+}
+
+// Make a cell for a struct field, with its default text and sort key.
+func makeCell(value interface{}) dataCell {
+	switch v := value.(type) {
+	case dbutil.Money:
+		return dataCell{text: v.ToTextField(), numeric: true, number: float64(v.Pennies), isNull: v.IsNull}
+	case dbutil.Percent:
+		return dataCell{text: v.ToTextField(), numeric: true, number: float64(v.Value), isNull: v.IsNull}
+	case dbutil.Boolean:
+		number := 0.0
+		if v.Value {
+			number = 1
+		}
+		return dataCell{text: v.ToTextField(), numeric: true, number: number, isNull: v.IsNull}
+	case dbutil.IdField:
+		return dataCell{text: v.ToTextField(), numeric: true, number: float64(v), isNull: v == 0}
+	case time.Time:
+		return dataCell{text: formatTime(v, "2006-01-02 15:04"), numeric: true,
+			number: float64(v.UnixNano()), isNull: v.IsZero()}
+	case fmt.Stringer:
+		return dataCell{text: v.String()}
+	case string:
+		return dataCell{text: v}
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return dataCell{text: fmt.Sprint(value), numeric: true, number: float64(rv.Int())}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return dataCell{text: fmt.Sprint(value), numeric: true, number: float64(rv.Uint())}
+	case reflect.Float32, reflect.Float64:
+		return dataCell{text: fmt.Sprint(value), numeric: true, number: rv.Float()}
+	}
+
+	return dataCell{text: fmt.Sprint(value)}
+}
+
+// Compare two cells of the same column, returning -1, 0, or 1.
+func compareCells(a, b dataCell) int {
+	switch {
+	case a.isNull && b.isNull:
+		return 0
+	case a.isNull:
+		return -1
+	case b.isNull:
+		return 1
+	case a.numeric && b.numeric:
+		if a.number < b.number {
+			return -1
+		}
+		if a.number > b.number {
+			return 1
+		}
+		return 0
+	}
+
+	return sortutil.CompareStringsNumerically(a.text, b.text)
+}
+
+// Sort the table by the "sort" and "dir" query parameters of the request,
+// which are what the column header links set. The request's URL is also
+// used to build those links.
+func (t *DataTable) SortFrom(r *http.Request) *DataTable {
+	t.url = r.URL
+
+	query := r.URL.Query()
+	key := query.Get("sort")
+	for i, column := range t.columns {
+		if column.key == key {
+			t.Sort(i, query.Get("dir") == "desc")
+			break
+		}
+	}
+
+	return t
+}
+
+// Sort the table by the column at index.
+func (t *DataTable) Sort(index int, descending bool) *DataTable {
+	t.sortIndex = index
+	t.sortDesc = descending
+
+	sort.SliceStable(t.rows, func(i, j int) bool {
+		c := compareCells(t.rows[i][index], t.rows[j][index])
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	return t
+}
+
+// Return the link that sorts by the column, toggling the direction if it's
+// the current sort column. Other query parameters are kept.
+func (t *DataTable) sortUrl(index int) string {
+	query := url.Values{}
+	if t.url != nil {
+		query = t.url.Query()
+	}
+
+	dir := "asc"
+	if index == t.sortIndex && !t.sortDesc {
+		dir = "desc"
+	}
+	query.Set("sort", t.columns[index].key)
+	query.Set("dir", dir)
+
+	return "?" + query.Encode()
+}
+
+// Write the table. The sorted column's header has the class "sorted-asc"
+// or "sorted-desc".
+func (t *DataTable) Render(h *HtmlResponse) {
+	h.Table(Class("data-table")).THead().Tr()
+	for i, column := range t.columns {
+		var attrs []attr
+		if i == t.sortIndex {
+			if t.sortDesc {
+				attrs = append(attrs, Class("sorted-desc"))
+			} else {
+				attrs = append(attrs, Class("sorted-asc"))
+			}
+		}
+		h.Th(attrs...).A(Href(t.sortUrl(i))).Content(column.header).Th_()
+	}
+	h.Tr_().THead_()
+
+	h.TBody()
+	for _, row := range t.rows {
+		h.Tr()
+		for _, cell := range row {
+			h.Td().Content(cell.text)
+		}
+		h.Tr_()
+	}
+	h.TBody_().Table_()
+}
@@ -0,0 +1,1278 @@
+// Code generated by gen_elements.go from spec/elements.txt; DO NOT EDIT.
+
+package snake
+
+// Opens <a>. Close it with A_().
+func (h *HtmlResponse) A(attrs ...attr) *HtmlResponse {
+	return h.openTag("a", attrs)
+}
+
+// Closes <a>.
+func (h *HtmlResponse) A_() *HtmlResponse {
+	return h.closeTag("a")
+}
+
+// Opens <abbr>. Close it with Abbr_().
+func (h *HtmlResponse) Abbr(attrs ...attr) *HtmlResponse {
+	return h.openTag("abbr", attrs)
+}
+
+// Closes <abbr>.
+func (h *HtmlResponse) Abbr_() *HtmlResponse {
+	return h.closeTag("abbr")
+}
+
+// Opens <address>. Close it with Address_().
+func (h *HtmlResponse) Address(attrs ...attr) *HtmlResponse {
+	return h.openTag("address", attrs)
+}
+
+// Closes <address>.
+func (h *HtmlResponse) Address_() *HtmlResponse {
+	return h.closeTag("address")
+}
+
+// Writes <area>, which has no closing tag.
+func (h *HtmlResponse) Area(attrs ...attr) *HtmlResponse {
+	return h.singleTag("area", attrs)
+}
+
+// Opens <article>. Close it with Article_().
+func (h *HtmlResponse) Article(attrs ...attr) *HtmlResponse {
+	return h.openTag("article", attrs)
+}
+
+// Closes <article>.
+func (h *HtmlResponse) Article_() *HtmlResponse {
+	return h.closeTag("article")
+}
+
+// Opens <aside>. Close it with Aside_().
+func (h *HtmlResponse) Aside(attrs ...attr) *HtmlResponse {
+	return h.openTag("aside", attrs)
+}
+
+// Closes <aside>.
+func (h *HtmlResponse) Aside_() *HtmlResponse {
+	return h.closeTag("aside")
+}
+
+// Opens <audio>. Close it with Audio_().
+func (h *HtmlResponse) Audio(attrs ...attr) *HtmlResponse {
+	return h.openTag("audio", attrs)
+}
+
+// Closes <audio>.
+func (h *HtmlResponse) Audio_() *HtmlResponse {
+	return h.closeTag("audio")
+}
+
+// Opens <b>. Close it with B_().
+func (h *HtmlResponse) B(attrs ...attr) *HtmlResponse {
+	return h.openTag("b", attrs)
+}
+
+// Closes <b>.
+func (h *HtmlResponse) B_() *HtmlResponse {
+	return h.closeTag("b")
+}
+
+// Writes <base>, which has no closing tag.
+func (h *HtmlResponse) Base(attrs ...attr) *HtmlResponse {
+	return h.singleTag("base", attrs)
+}
+
+// Opens <bdi>. Close it with Bdi_().
+func (h *HtmlResponse) Bdi(attrs ...attr) *HtmlResponse {
+	return h.openTag("bdi", attrs)
+}
+
+// Closes <bdi>.
+func (h *HtmlResponse) Bdi_() *HtmlResponse {
+	return h.closeTag("bdi")
+}
+
+// Opens <bdo>. Close it with Bdo_().
+func (h *HtmlResponse) Bdo(attrs ...attr) *HtmlResponse {
+	return h.openTag("bdo", attrs)
+}
+
+// Closes <bdo>.
+func (h *HtmlResponse) Bdo_() *HtmlResponse {
+	return h.closeTag("bdo")
+}
+
+// Opens <blockquote>. Close it with BlockQuote_().
+func (h *HtmlResponse) BlockQuote(attrs ...attr) *HtmlResponse {
+	return h.openTag("blockquote", attrs)
+}
+
+// Closes <blockquote>.
+func (h *HtmlResponse) BlockQuote_() *HtmlResponse {
+	return h.closeTag("blockquote")
+}
+
+// Opens <body>. Close it with Body_().
+func (h *HtmlResponse) Body(attrs ...attr) *HtmlResponse {
+	return h.openTag("body", attrs)
+}
+
+// Closes <body>.
+func (h *HtmlResponse) Body_() *HtmlResponse {
+	return h.closeTag("body")
+}
+
+// Writes <br>, which has no closing tag.
+func (h *HtmlResponse) Br(attrs ...attr) *HtmlResponse {
+	return h.singleTag("br", attrs)
+}
+
+// Opens <button>. Close it with Button_().
+func (h *HtmlResponse) Button(attrs ...attr) *HtmlResponse {
+	return h.openTag("button", attrs)
+}
+
+// Closes <button>.
+func (h *HtmlResponse) Button_() *HtmlResponse {
+	return h.closeTag("button")
+}
+
+// Opens <canvas>. Close it with Canvas_().
+func (h *HtmlResponse) Canvas(attrs ...attr) *HtmlResponse {
+	return h.openTag("canvas", attrs)
+}
+
+// Closes <canvas>.
+func (h *HtmlResponse) Canvas_() *HtmlResponse {
+	return h.closeTag("canvas")
+}
+
+// Opens <caption>. Close it with Caption_().
+func (h *HtmlResponse) Caption(attrs ...attr) *HtmlResponse {
+	return h.openTag("caption", attrs)
+}
+
+// Closes <caption>.
+func (h *HtmlResponse) Caption_() *HtmlResponse {
+	return h.closeTag("caption")
+}
+
+// Opens <cite>. Close it with Cite_().
+func (h *HtmlResponse) Cite(attrs ...attr) *HtmlResponse {
+	return h.openTag("cite", attrs)
+}
+
+// Closes <cite>.
+func (h *HtmlResponse) Cite_() *HtmlResponse {
+	return h.closeTag("cite")
+}
+
+// Opens <code>. Close it with Code_().
+func (h *HtmlResponse) Code(attrs ...attr) *HtmlResponse {
+	return h.openTag("code", attrs)
+}
+
+// Closes <code>.
+func (h *HtmlResponse) Code_() *HtmlResponse {
+	return h.closeTag("code")
+}
+
+// Writes <col>, which has no closing tag.
+func (h *HtmlResponse) Col(attrs ...attr) *HtmlResponse {
+	return h.singleTag("col", attrs)
+}
+
+// Opens <colgroup>. Close it with ColGroup_().
+func (h *HtmlResponse) ColGroup(attrs ...attr) *HtmlResponse {
+	return h.openTag("colgroup", attrs)
+}
+
+// Closes <colgroup>.
+func (h *HtmlResponse) ColGroup_() *HtmlResponse {
+	return h.closeTag("colgroup")
+}
+
+// Opens <data>. Close it with Data_().
+func (h *HtmlResponse) Data(attrs ...attr) *HtmlResponse {
+	return h.openTag("data", attrs)
+}
+
+// Closes <data>.
+func (h *HtmlResponse) Data_() *HtmlResponse {
+	return h.closeTag("data")
+}
+
+// Opens <datalist>. Close it with DataList_().
+func (h *HtmlResponse) DataList(attrs ...attr) *HtmlResponse {
+	return h.openTag("datalist", attrs)
+}
+
+// Closes <datalist>.
+func (h *HtmlResponse) DataList_() *HtmlResponse {
+	return h.closeTag("datalist")
+}
+
+// Opens <dd>. Close it with Dd_().
+func (h *HtmlResponse) Dd(attrs ...attr) *HtmlResponse {
+	return h.openTag("dd", attrs)
+}
+
+// Closes <dd>.
+func (h *HtmlResponse) Dd_() *HtmlResponse {
+	return h.closeTag("dd")
+}
+
+// Opens <del>. Close it with Del_().
+func (h *HtmlResponse) Del(attrs ...attr) *HtmlResponse {
+	return h.openTag("del", attrs)
+}
+
+// Closes <del>.
+func (h *HtmlResponse) Del_() *HtmlResponse {
+	return h.closeTag("del")
+}
+
+// Opens <details>. Close it with Details_().
+func (h *HtmlResponse) Details(attrs ...attr) *HtmlResponse {
+	return h.openTag("details", attrs)
+}
+
+// Closes <details>.
+func (h *HtmlResponse) Details_() *HtmlResponse {
+	return h.closeTag("details")
+}
+
+// Opens <dfn>. Close it with Dfn_().
+func (h *HtmlResponse) Dfn(attrs ...attr) *HtmlResponse {
+	return h.openTag("dfn", attrs)
+}
+
+// Closes <dfn>.
+func (h *HtmlResponse) Dfn_() *HtmlResponse {
+	return h.closeTag("dfn")
+}
+
+// Opens <dialog>. Close it with Dialog_().
+func (h *HtmlResponse) Dialog(attrs ...attr) *HtmlResponse {
+	return h.openTag("dialog", attrs)
+}
+
+// Closes <dialog>.
+func (h *HtmlResponse) Dialog_() *HtmlResponse {
+	return h.closeTag("dialog")
+}
+
+// Opens <div>. Close it with Div_().
+func (h *HtmlResponse) Div(attrs ...attr) *HtmlResponse {
+	return h.openTag("div", attrs)
+}
+
+// Closes <div>.
+func (h *HtmlResponse) Div_() *HtmlResponse {
+	return h.closeTag("div")
+}
+
+// Opens <dl>. Close it with Dl_().
+func (h *HtmlResponse) Dl(attrs ...attr) *HtmlResponse {
+	return h.openTag("dl", attrs)
+}
+
+// Closes <dl>.
+func (h *HtmlResponse) Dl_() *HtmlResponse {
+	return h.closeTag("dl")
+}
+
+// Opens <dt>. Close it with Dt_().
+func (h *HtmlResponse) Dt(attrs ...attr) *HtmlResponse {
+	return h.openTag("dt", attrs)
+}
+
+// Closes <dt>.
+func (h *HtmlResponse) Dt_() *HtmlResponse {
+	return h.closeTag("dt")
+}
+
+// Opens <em>. Close it with Em_().
+func (h *HtmlResponse) Em(attrs ...attr) *HtmlResponse {
+	return h.openTag("em", attrs)
+}
+
+// Closes <em>.
+func (h *HtmlResponse) Em_() *HtmlResponse {
+	return h.closeTag("em")
+}
+
+// Writes <embed>, which has no closing tag.
+func (h *HtmlResponse) Embed(attrs ...attr) *HtmlResponse {
+	return h.singleTag("embed", attrs)
+}
+
+// Opens <fieldset>. Close it with FieldSet_().
+func (h *HtmlResponse) FieldSet(attrs ...attr) *HtmlResponse {
+	return h.openTag("fieldset", attrs)
+}
+
+// Closes <fieldset>.
+func (h *HtmlResponse) FieldSet_() *HtmlResponse {
+	return h.closeTag("fieldset")
+}
+
+// Opens <figcaption>. Close it with FigCaption_().
+func (h *HtmlResponse) FigCaption(attrs ...attr) *HtmlResponse {
+	return h.openTag("figcaption", attrs)
+}
+
+// Closes <figcaption>.
+func (h *HtmlResponse) FigCaption_() *HtmlResponse {
+	return h.closeTag("figcaption")
+}
+
+// Opens <figure>. Close it with Figure_().
+func (h *HtmlResponse) Figure(attrs ...attr) *HtmlResponse {
+	return h.openTag("figure", attrs)
+}
+
+// Closes <figure>.
+func (h *HtmlResponse) Figure_() *HtmlResponse {
+	return h.closeTag("figure")
+}
+
+// Opens <footer>. Close it with Footer_().
+func (h *HtmlResponse) Footer(attrs ...attr) *HtmlResponse {
+	return h.openTag("footer", attrs)
+}
+
+// Closes <footer>.
+func (h *HtmlResponse) Footer_() *HtmlResponse {
+	return h.closeTag("footer")
+}
+
+// Opens <form>. Close it with Form_().
+func (h *HtmlResponse) Form(attrs ...attr) *HtmlResponse {
+	return h.openTag("form", attrs)
+}
+
+// Closes <form>.
+func (h *HtmlResponse) Form_() *HtmlResponse {
+	return h.closeTag("form")
+}
+
+// Opens <h1>. Close it with H1_().
+func (h *HtmlResponse) H1(attrs ...attr) *HtmlResponse {
+	return h.openTag("h1", attrs)
+}
+
+// Closes <h1>.
+func (h *HtmlResponse) H1_() *HtmlResponse {
+	return h.closeTag("h1")
+}
+
+// Opens <h2>. Close it with H2_().
+func (h *HtmlResponse) H2(attrs ...attr) *HtmlResponse {
+	return h.openTag("h2", attrs)
+}
+
+// Closes <h2>.
+func (h *HtmlResponse) H2_() *HtmlResponse {
+	return h.closeTag("h2")
+}
+
+// Opens <h3>. Close it with H3_().
+func (h *HtmlResponse) H3(attrs ...attr) *HtmlResponse {
+	return h.openTag("h3", attrs)
+}
+
+// Closes <h3>.
+func (h *HtmlResponse) H3_() *HtmlResponse {
+	return h.closeTag("h3")
+}
+
+// Opens <h4>. Close it with H4_().
+func (h *HtmlResponse) H4(attrs ...attr) *HtmlResponse {
+	return h.openTag("h4", attrs)
+}
+
+// Closes <h4>.
+func (h *HtmlResponse) H4_() *HtmlResponse {
+	return h.closeTag("h4")
+}
+
+// Opens <h5>. Close it with H5_().
+func (h *HtmlResponse) H5(attrs ...attr) *HtmlResponse {
+	return h.openTag("h5", attrs)
+}
+
+// Closes <h5>.
+func (h *HtmlResponse) H5_() *HtmlResponse {
+	return h.closeTag("h5")
+}
+
+// Opens <h6>. Close it with H6_().
+func (h *HtmlResponse) H6(attrs ...attr) *HtmlResponse {
+	return h.openTag("h6", attrs)
+}
+
+// Closes <h6>.
+func (h *HtmlResponse) H6_() *HtmlResponse {
+	return h.closeTag("h6")
+}
+
+// Opens <head>. Close it with Head_().
+func (h *HtmlResponse) Head(attrs ...attr) *HtmlResponse {
+	return h.openTag("head", attrs)
+}
+
+// Closes <head>.
+func (h *HtmlResponse) Head_() *HtmlResponse {
+	return h.closeTag("head")
+}
+
+// Opens <header>. Close it with Header_().
+func (h *HtmlResponse) Header(attrs ...attr) *HtmlResponse {
+	return h.openTag("header", attrs)
+}
+
+// Closes <header>.
+func (h *HtmlResponse) Header_() *HtmlResponse {
+	return h.closeTag("header")
+}
+
+// Opens <hgroup>. Close it with HGroup_().
+func (h *HtmlResponse) HGroup(attrs ...attr) *HtmlResponse {
+	return h.openTag("hgroup", attrs)
+}
+
+// Closes <hgroup>.
+func (h *HtmlResponse) HGroup_() *HtmlResponse {
+	return h.closeTag("hgroup")
+}
+
+// Writes <hr>, which has no closing tag.
+func (h *HtmlResponse) Hr(attrs ...attr) *HtmlResponse {
+	return h.singleTag("hr", attrs)
+}
+
+// Opens <html>. Close it with Html_().
+func (h *HtmlResponse) Html(attrs ...attr) *HtmlResponse {
+	return h.openTag("html", attrs)
+}
+
+// Closes <html>.
+func (h *HtmlResponse) Html_() *HtmlResponse {
+	return h.closeTag("html")
+}
+
+// Opens <i>. Close it with I_().
+func (h *HtmlResponse) I(attrs ...attr) *HtmlResponse {
+	return h.openTag("i", attrs)
+}
+
+// Closes <i>.
+func (h *HtmlResponse) I_() *HtmlResponse {
+	return h.closeTag("i")
+}
+
+// Opens <iframe>. Close it with IFrame_().
+func (h *HtmlResponse) IFrame(attrs ...attr) *HtmlResponse {
+	return h.openTag("iframe", attrs)
+}
+
+// Closes <iframe>.
+func (h *HtmlResponse) IFrame_() *HtmlResponse {
+	return h.closeTag("iframe")
+}
+
+// Writes <img>, which has no closing tag.
+func (h *HtmlResponse) Img(attrs ...attr) *HtmlResponse {
+	return h.singleTag("img", attrs)
+}
+
+// Writes <input>, which has no closing tag.
+func (h *HtmlResponse) Input(attrs ...attr) *HtmlResponse {
+	return h.singleTag("input", attrs)
+}
+
+// Opens <ins>. Close it with Ins_().
+func (h *HtmlResponse) Ins(attrs ...attr) *HtmlResponse {
+	return h.openTag("ins", attrs)
+}
+
+// Closes <ins>.
+func (h *HtmlResponse) Ins_() *HtmlResponse {
+	return h.closeTag("ins")
+}
+
+// Opens <kbd>. Close it with Kbd_().
+func (h *HtmlResponse) Kbd(attrs ...attr) *HtmlResponse {
+	return h.openTag("kbd", attrs)
+}
+
+// Closes <kbd>.
+func (h *HtmlResponse) Kbd_() *HtmlResponse {
+	return h.closeTag("kbd")
+}
+
+// Opens <label>. Close it with Label_().
+func (h *HtmlResponse) Label(attrs ...attr) *HtmlResponse {
+	return h.openTag("label", attrs)
+}
+
+// Closes <label>.
+func (h *HtmlResponse) Label_() *HtmlResponse {
+	return h.closeTag("label")
+}
+
+// Opens <legend>. Close it with Legend_().
+func (h *HtmlResponse) Legend(attrs ...attr) *HtmlResponse {
+	return h.openTag("legend", attrs)
+}
+
+// Closes <legend>.
+func (h *HtmlResponse) Legend_() *HtmlResponse {
+	return h.closeTag("legend")
+}
+
+// Opens <li>. Close it with Li_().
+func (h *HtmlResponse) Li(attrs ...attr) *HtmlResponse {
+	return h.openTag("li", attrs)
+}
+
+// Closes <li>.
+func (h *HtmlResponse) Li_() *HtmlResponse {
+	return h.closeTag("li")
+}
+
+// Writes <link>, which has no closing tag.
+func (h *HtmlResponse) Link(attrs ...attr) *HtmlResponse {
+	return h.singleTag("link", attrs)
+}
+
+// Opens <main>. Close it with Main_().
+func (h *HtmlResponse) Main(attrs ...attr) *HtmlResponse {
+	return h.openTag("main", attrs)
+}
+
+// Closes <main>.
+func (h *HtmlResponse) Main_() *HtmlResponse {
+	return h.closeTag("main")
+}
+
+// Opens <map>. Close it with Map_().
+func (h *HtmlResponse) Map(attrs ...attr) *HtmlResponse {
+	return h.openTag("map", attrs)
+}
+
+// Closes <map>.
+func (h *HtmlResponse) Map_() *HtmlResponse {
+	return h.closeTag("map")
+}
+
+// Opens <mark>. Close it with Mark_().
+func (h *HtmlResponse) Mark(attrs ...attr) *HtmlResponse {
+	return h.openTag("mark", attrs)
+}
+
+// Closes <mark>.
+func (h *HtmlResponse) Mark_() *HtmlResponse {
+	return h.closeTag("mark")
+}
+
+// Opens <menu>. Close it with Menu_().
+func (h *HtmlResponse) Menu(attrs ...attr) *HtmlResponse {
+	return h.openTag("menu", attrs)
+}
+
+// Closes <menu>.
+func (h *HtmlResponse) Menu_() *HtmlResponse {
+	return h.closeTag("menu")
+}
+
+// Writes <meta>, which has no closing tag.
+func (h *HtmlResponse) Meta(attrs ...attr) *HtmlResponse {
+	return h.singleTag("meta", attrs)
+}
+
+// Opens <meter>. Close it with Meter_().
+func (h *HtmlResponse) Meter(attrs ...attr) *HtmlResponse {
+	return h.openTag("meter", attrs)
+}
+
+// Closes <meter>.
+func (h *HtmlResponse) Meter_() *HtmlResponse {
+	return h.closeTag("meter")
+}
+
+// Opens <nav>. Close it with Nav_().
+func (h *HtmlResponse) Nav(attrs ...attr) *HtmlResponse {
+	return h.openTag("nav", attrs)
+}
+
+// Closes <nav>.
+func (h *HtmlResponse) Nav_() *HtmlResponse {
+	return h.closeTag("nav")
+}
+
+// Opens <noscript>. Close it with NoScript_().
+func (h *HtmlResponse) NoScript(attrs ...attr) *HtmlResponse {
+	return h.openTag("noscript", attrs)
+}
+
+// Closes <noscript>.
+func (h *HtmlResponse) NoScript_() *HtmlResponse {
+	return h.closeTag("noscript")
+}
+
+// Opens <object>. Close it with Object_().
+func (h *HtmlResponse) Object(attrs ...attr) *HtmlResponse {
+	return h.openTag("object", attrs)
+}
+
+// Closes <object>.
+func (h *HtmlResponse) Object_() *HtmlResponse {
+	return h.closeTag("object")
+}
+
+// Opens <ol>. Close it with Ol_().
+func (h *HtmlResponse) Ol(attrs ...attr) *HtmlResponse {
+	return h.openTag("ol", attrs)
+}
+
+// Closes <ol>.
+func (h *HtmlResponse) Ol_() *HtmlResponse {
+	return h.closeTag("ol")
+}
+
+// Opens <optgroup>. Close it with OptGroup_().
+func (h *HtmlResponse) OptGroup(attrs ...attr) *HtmlResponse {
+	return h.openTag("optgroup", attrs)
+}
+
+// Closes <optgroup>.
+func (h *HtmlResponse) OptGroup_() *HtmlResponse {
+	return h.closeTag("optgroup")
+}
+
+// Opens <option>. Close it with Option_().
+func (h *HtmlResponse) Option(attrs ...attr) *HtmlResponse {
+	return h.openTag("option", attrs)
+}
+
+// Closes <option>.
+func (h *HtmlResponse) Option_() *HtmlResponse {
+	return h.closeTag("option")
+}
+
+// Opens <output>. Close it with Output_().
+func (h *HtmlResponse) Output(attrs ...attr) *HtmlResponse {
+	return h.openTag("output", attrs)
+}
+
+// Closes <output>.
+func (h *HtmlResponse) Output_() *HtmlResponse {
+	return h.closeTag("output")
+}
+
+// Opens <p>. Close it with P_().
+func (h *HtmlResponse) P(attrs ...attr) *HtmlResponse {
+	return h.openTag("p", attrs)
+}
+
+// Closes <p>.
+func (h *HtmlResponse) P_() *HtmlResponse {
+	return h.closeTag("p")
+}
+
+// Opens <picture>. Close it with Picture_().
+func (h *HtmlResponse) Picture(attrs ...attr) *HtmlResponse {
+	return h.openTag("picture", attrs)
+}
+
+// Closes <picture>.
+func (h *HtmlResponse) Picture_() *HtmlResponse {
+	return h.closeTag("picture")
+}
+
+// Opens <pre>. Close it with Pre_().
+func (h *HtmlResponse) Pre(attrs ...attr) *HtmlResponse {
+	return h.openTag("pre", attrs)
+}
+
+// Closes <pre>.
+func (h *HtmlResponse) Pre_() *HtmlResponse {
+	return h.closeTag("pre")
+}
+
+// Opens <progress>. Close it with Progress_().
+func (h *HtmlResponse) Progress(attrs ...attr) *HtmlResponse {
+	return h.openTag("progress", attrs)
+}
+
+// Closes <progress>.
+func (h *HtmlResponse) Progress_() *HtmlResponse {
+	return h.closeTag("progress")
+}
+
+// Opens <q>. Close it with Q_().
+func (h *HtmlResponse) Q(attrs ...attr) *HtmlResponse {
+	return h.openTag("q", attrs)
+}
+
+// Closes <q>.
+func (h *HtmlResponse) Q_() *HtmlResponse {
+	return h.closeTag("q")
+}
+
+// Opens <rp>. Close it with Rp_().
+func (h *HtmlResponse) Rp(attrs ...attr) *HtmlResponse {
+	return h.openTag("rp", attrs)
+}
+
+// Closes <rp>.
+func (h *HtmlResponse) Rp_() *HtmlResponse {
+	return h.closeTag("rp")
+}
+
+// Opens <rt>. Close it with Rt_().
+func (h *HtmlResponse) Rt(attrs ...attr) *HtmlResponse {
+	return h.openTag("rt", attrs)
+}
+
+// Closes <rt>.
+func (h *HtmlResponse) Rt_() *HtmlResponse {
+	return h.closeTag("rt")
+}
+
+// Opens <ruby>. Close it with Ruby_().
+func (h *HtmlResponse) Ruby(attrs ...attr) *HtmlResponse {
+	return h.openTag("ruby", attrs)
+}
+
+// Closes <ruby>.
+func (h *HtmlResponse) Ruby_() *HtmlResponse {
+	return h.closeTag("ruby")
+}
+
+// Opens <s>. Close it with S_().
+func (h *HtmlResponse) S(attrs ...attr) *HtmlResponse {
+	return h.openTag("s", attrs)
+}
+
+// Closes <s>.
+func (h *HtmlResponse) S_() *HtmlResponse {
+	return h.closeTag("s")
+}
+
+// Opens <samp>. Close it with Samp_().
+func (h *HtmlResponse) Samp(attrs ...attr) *HtmlResponse {
+	return h.openTag("samp", attrs)
+}
+
+// Closes <samp>.
+func (h *HtmlResponse) Samp_() *HtmlResponse {
+	return h.closeTag("samp")
+}
+
+// Opens <script>. Close it with Script_().
+func (h *HtmlResponse) Script(attrs ...attr) *HtmlResponse {
+	return h.openTag("script", attrs)
+}
+
+// Closes <script>.
+func (h *HtmlResponse) Script_() *HtmlResponse {
+	return h.closeTag("script")
+}
+
+// Opens <search>. Close it with Search_().
+func (h *HtmlResponse) Search(attrs ...attr) *HtmlResponse {
+	return h.openTag("search", attrs)
+}
+
+// Closes <search>.
+func (h *HtmlResponse) Search_() *HtmlResponse {
+	return h.closeTag("search")
+}
+
+// Opens <section>. Close it with Section_().
+func (h *HtmlResponse) Section(attrs ...attr) *HtmlResponse {
+	return h.openTag("section", attrs)
+}
+
+// Closes <section>.
+func (h *HtmlResponse) Section_() *HtmlResponse {
+	return h.closeTag("section")
+}
+
+// Opens <select>. Close it with Select_().
+func (h *HtmlResponse) Select(attrs ...attr) *HtmlResponse {
+	return h.openTag("select", attrs)
+}
+
+// Closes <select>.
+func (h *HtmlResponse) Select_() *HtmlResponse {
+	return h.closeTag("select")
+}
+
+// Opens <slot>. Close it with Slot_().
+func (h *HtmlResponse) Slot(attrs ...attr) *HtmlResponse {
+	return h.openTag("slot", attrs)
+}
+
+// Closes <slot>.
+func (h *HtmlResponse) Slot_() *HtmlResponse {
+	return h.closeTag("slot")
+}
+
+// Opens <small>. Close it with Small_().
+func (h *HtmlResponse) Small(attrs ...attr) *HtmlResponse {
+	return h.openTag("small", attrs)
+}
+
+// Closes <small>.
+func (h *HtmlResponse) Small_() *HtmlResponse {
+	return h.closeTag("small")
+}
+
+// Writes <source>, which has no closing tag.
+func (h *HtmlResponse) Source(attrs ...attr) *HtmlResponse {
+	return h.singleTag("source", attrs)
+}
+
+// Opens <span>. Close it with Span_().
+func (h *HtmlResponse) Span(attrs ...attr) *HtmlResponse {
+	return h.openTag("span", attrs)
+}
+
+// Closes <span>.
+func (h *HtmlResponse) Span_() *HtmlResponse {
+	return h.closeTag("span")
+}
+
+// Opens <strong>. Close it with Strong_().
+func (h *HtmlResponse) Strong(attrs ...attr) *HtmlResponse {
+	return h.openTag("strong", attrs)
+}
+
+// Closes <strong>.
+func (h *HtmlResponse) Strong_() *HtmlResponse {
+	return h.closeTag("strong")
+}
+
+// Opens <style>. Close it with Style_().
+func (h *HtmlResponse) Style(attrs ...attr) *HtmlResponse {
+	return h.openTag("style", attrs)
+}
+
+// Closes <style>.
+func (h *HtmlResponse) Style_() *HtmlResponse {
+	return h.closeTag("style")
+}
+
+// Opens <sub>. Close it with Sub_().
+func (h *HtmlResponse) Sub(attrs ...attr) *HtmlResponse {
+	return h.openTag("sub", attrs)
+}
+
+// Closes <sub>.
+func (h *HtmlResponse) Sub_() *HtmlResponse {
+	return h.closeTag("sub")
+}
+
+// Opens <summary>. Close it with Summary_().
+func (h *HtmlResponse) Summary(attrs ...attr) *HtmlResponse {
+	return h.openTag("summary", attrs)
+}
+
+// Closes <summary>.
+func (h *HtmlResponse) Summary_() *HtmlResponse {
+	return h.closeTag("summary")
+}
+
+// Opens <sup>. Close it with Sup_().
+func (h *HtmlResponse) Sup(attrs ...attr) *HtmlResponse {
+	return h.openTag("sup", attrs)
+}
+
+// Closes <sup>.
+func (h *HtmlResponse) Sup_() *HtmlResponse {
+	return h.closeTag("sup")
+}
+
+// Opens <table>. Close it with Table_().
+func (h *HtmlResponse) Table(attrs ...attr) *HtmlResponse {
+	return h.openTag("table", attrs)
+}
+
+// Closes <table>.
+func (h *HtmlResponse) Table_() *HtmlResponse {
+	return h.closeTag("table")
+}
+
+// Opens <tbody>. Close it with TBody_().
+func (h *HtmlResponse) TBody(attrs ...attr) *HtmlResponse {
+	return h.openTag("tbody", attrs)
+}
+
+// Closes <tbody>.
+func (h *HtmlResponse) TBody_() *HtmlResponse {
+	return h.closeTag("tbody")
+}
+
+// Opens <td>. Close it with Td_().
+func (h *HtmlResponse) Td(attrs ...attr) *HtmlResponse {
+	return h.openTag("td", attrs)
+}
+
+// Closes <td>.
+func (h *HtmlResponse) Td_() *HtmlResponse {
+	return h.closeTag("td")
+}
+
+// Opens <template>. Close it with Template_().
+func (h *HtmlResponse) Template(attrs ...attr) *HtmlResponse {
+	return h.openTag("template", attrs)
+}
+
+// Closes <template>.
+func (h *HtmlResponse) Template_() *HtmlResponse {
+	return h.closeTag("template")
+}
+
+// Opens <textarea>. Close it with TextArea_().
+func (h *HtmlResponse) TextArea(attrs ...attr) *HtmlResponse {
+	return h.openTag("textarea", attrs)
+}
+
+// Closes <textarea>.
+func (h *HtmlResponse) TextArea_() *HtmlResponse {
+	return h.closeTag("textarea")
+}
+
+// Opens <tfoot>. Close it with TFoot_().
+func (h *HtmlResponse) TFoot(attrs ...attr) *HtmlResponse {
+	return h.openTag("tfoot", attrs)
+}
+
+// Closes <tfoot>.
+func (h *HtmlResponse) TFoot_() *HtmlResponse {
+	return h.closeTag("tfoot")
+}
+
+// Opens <th>. Close it with Th_().
+func (h *HtmlResponse) Th(attrs ...attr) *HtmlResponse {
+	return h.openTag("th", attrs)
+}
+
+// Closes <th>.
+func (h *HtmlResponse) Th_() *HtmlResponse {
+	return h.closeTag("th")
+}
+
+// Opens <thead>. Close it with THead_().
+func (h *HtmlResponse) THead(attrs ...attr) *HtmlResponse {
+	return h.openTag("thead", attrs)
+}
+
+// Closes <thead>.
+func (h *HtmlResponse) THead_() *HtmlResponse {
+	return h.closeTag("thead")
+}
+
+// Opens <time>. Close it with Time_().
+func (h *HtmlResponse) Time(attrs ...attr) *HtmlResponse {
+	return h.openTag("time", attrs)
+}
+
+// Closes <time>.
+func (h *HtmlResponse) Time_() *HtmlResponse {
+	return h.closeTag("time")
+}
+
+// Opens <title>. Close it with Title_().
+func (h *HtmlResponse) Title(attrs ...attr) *HtmlResponse {
+	return h.openTag("title", attrs)
+}
+
+// Closes <title>.
+func (h *HtmlResponse) Title_() *HtmlResponse {
+	return h.closeTag("title")
+}
+
+// Opens <tr>. Close it with Tr_().
+func (h *HtmlResponse) Tr(attrs ...attr) *HtmlResponse {
+	return h.openTag("tr", attrs)
+}
+
+// Closes <tr>.
+func (h *HtmlResponse) Tr_() *HtmlResponse {
+	return h.closeTag("tr")
+}
+
+// Writes <track>, which has no closing tag.
+func (h *HtmlResponse) Track(attrs ...attr) *HtmlResponse {
+	return h.singleTag("track", attrs)
+}
+
+// Opens <u>. Close it with U_().
+func (h *HtmlResponse) U(attrs ...attr) *HtmlResponse {
+	return h.openTag("u", attrs)
+}
+
+// Closes <u>.
+func (h *HtmlResponse) U_() *HtmlResponse {
+	return h.closeTag("u")
+}
+
+// Opens <ul>. Close it with Ul_().
+func (h *HtmlResponse) Ul(attrs ...attr) *HtmlResponse {
+	return h.openTag("ul", attrs)
+}
+
+// Closes <ul>.
+func (h *HtmlResponse) Ul_() *HtmlResponse {
+	return h.closeTag("ul")
+}
+
+// Opens <var>. Close it with Var_().
+func (h *HtmlResponse) Var(attrs ...attr) *HtmlResponse {
+	return h.openTag("var", attrs)
+}
+
+// Closes <var>.
+func (h *HtmlResponse) Var_() *HtmlResponse {
+	return h.closeTag("var")
+}
+
+// Opens <video>. Close it with Video_().
+func (h *HtmlResponse) Video(attrs ...attr) *HtmlResponse {
+	return h.openTag("video", attrs)
+}
+
+// Closes <video>.
+func (h *HtmlResponse) Video_() *HtmlResponse {
+	return h.closeTag("video")
+}
+
+// Writes <wbr>, which has no closing tag.
+func (h *HtmlResponse) Wbr(attrs ...attr) *HtmlResponse {
+	return h.singleTag("wbr", attrs)
+}
+
+// Elements that have no closing tag.
+var voidElements = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"link":   true,
+	"meta":   true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
+
+// Elements whose closing tag may be omitted.
+var optionalEndTags = map[string]bool{
+	"body":     true,
+	"dd":       true,
+	"head":     true,
+	"html":     true,
+	"li":       true,
+	"optgroup": true,
+	"option":   true,
+	"rp":       true,
+	"rt":       true,
+	"tbody":    true,
+	"td":       true,
+	"tfoot":    true,
+	"th":       true,
+	"tr":       true,
+}
+
+// Elements that pretty-printing puts on their own line.
+var blockElements = map[string]bool{
+	"address":    true,
+	"article":    true,
+	"aside":      true,
+	"base":       true,
+	"blockquote": true,
+	"body":       true,
+	"caption":    true,
+	"col":        true,
+	"colgroup":   true,
+	"dd":         true,
+	"details":    true,
+	"dialog":     true,
+	"div":        true,
+	"dl":         true,
+	"dt":         true,
+	"fieldset":   true,
+	"figcaption": true,
+	"figure":     true,
+	"footer":     true,
+	"form":       true,
+	"h1":         true,
+	"h2":         true,
+	"h3":         true,
+	"h4":         true,
+	"h5":         true,
+	"h6":         true,
+	"head":       true,
+	"header":     true,
+	"hgroup":     true,
+	"hr":         true,
+	"html":       true,
+	"legend":     true,
+	"li":         true,
+	"link":       true,
+	"main":       true,
+	"menu":       true,
+	"meta":       true,
+	"nav":        true,
+	"noscript":   true,
+	"ol":         true,
+	"optgroup":   true,
+	"option":     true,
+	"p":          true,
+	"search":     true,
+	"section":    true,
+	"select":     true,
+	"summary":    true,
+	"table":      true,
+	"tbody":      true,
+	"td":         true,
+	"template":   true,
+	"tfoot":      true,
+	"th":         true,
+	"thead":      true,
+	"title":      true,
+	"tr":         true,
+	"ul":         true,
+}
+
+// Elements whose contents are whitespace-sensitive.
+var preformattedElements = map[string]bool{
+	"pre":      true,
+	"script":   true,
+	"style":    true,
+	"textarea": true,
+}
+
+// Methods for HTML elements, by tag.
+var elementInfos = map[string]ElementInfo{
+	"a":          {Method: "A"},
+	"abbr":       {Method: "Abbr"},
+	"address":    {Method: "Address", Block: true},
+	"area":       {Method: "Area", Void: true},
+	"article":    {Method: "Article", Block: true},
+	"aside":      {Method: "Aside", Block: true},
+	"audio":      {Method: "Audio"},
+	"b":          {Method: "B"},
+	"base":       {Method: "Base", Void: true, Block: true},
+	"bdi":        {Method: "Bdi"},
+	"bdo":        {Method: "Bdo"},
+	"blockquote": {Method: "BlockQuote", Block: true},
+	"body":       {Method: "Body", Block: true},
+	"br":         {Method: "Br", Void: true},
+	"button":     {Method: "Button"},
+	"canvas":     {Method: "Canvas"},
+	"caption":    {Method: "Caption", Block: true},
+	"cite":       {Method: "Cite"},
+	"code":       {Method: "Code"},
+	"col":        {Method: "Col", Void: true, Block: true},
+	"colgroup":   {Method: "ColGroup", Block: true},
+	"data":       {Method: "Data"},
+	"datalist":   {Method: "DataList"},
+	"dd":         {Method: "Dd", Block: true},
+	"del":        {Method: "Del"},
+	"details":    {Method: "Details", Block: true},
+	"dfn":        {Method: "Dfn"},
+	"dialog":     {Method: "Dialog", Block: true},
+	"div":        {Method: "Div", Block: true},
+	"dl":         {Method: "Dl", Block: true},
+	"dt":         {Method: "Dt", Block: true},
+	"em":         {Method: "Em"},
+	"embed":      {Method: "Embed", Void: true},
+	"fieldset":   {Method: "FieldSet", Block: true},
+	"figcaption": {Method: "FigCaption", Block: true},
+	"figure":     {Method: "Figure", Block: true},
+	"footer":     {Method: "Footer", Block: true},
+	"form":       {Method: "Form", Block: true},
+	"h1":         {Method: "H1", Block: true},
+	"h2":         {Method: "H2", Block: true},
+	"h3":         {Method: "H3", Block: true},
+	"h4":         {Method: "H4", Block: true},
+	"h5":         {Method: "H5", Block: true},
+	"h6":         {Method: "H6", Block: true},
+	"head":       {Method: "Head", Block: true},
+	"header":     {Method: "Header", Block: true},
+	"hgroup":     {Method: "HGroup", Block: true},
+	"hr":         {Method: "Hr", Void: true, Block: true},
+	"html":       {Method: "Html", Block: true},
+	"i":          {Method: "I"},
+	"iframe":     {Method: "IFrame"},
+	"img":        {Method: "Img", Void: true},
+	"input":      {Method: "Input", Void: true},
+	"ins":        {Method: "Ins"},
+	"kbd":        {Method: "Kbd"},
+	"label":      {Method: "Label"},
+	"legend":     {Method: "Legend", Block: true},
+	"li":         {Method: "Li", Block: true},
+	"link":       {Method: "Link", Void: true, Block: true},
+	"main":       {Method: "Main", Block: true},
+	"map":        {Method: "Map"},
+	"mark":       {Method: "Mark"},
+	"menu":       {Method: "Menu", Block: true},
+	"meta":       {Method: "Meta", Void: true, Block: true},
+	"meter":      {Method: "Meter"},
+	"nav":        {Method: "Nav", Block: true},
+	"noscript":   {Method: "NoScript", Block: true},
+	"object":     {Method: "Object"},
+	"ol":         {Method: "Ol", Block: true},
+	"optgroup":   {Method: "OptGroup", Block: true},
+	"option":     {Method: "Option", Block: true},
+	"output":     {Method: "Output"},
+	"p":          {Method: "P", Block: true},
+	"picture":    {Method: "Picture"},
+	"pre":        {Method: "Pre", Block: true},
+	"progress":   {Method: "Progress"},
+	"q":          {Method: "Q"},
+	"rp":         {Method: "Rp"},
+	"rt":         {Method: "Rt"},
+	"ruby":       {Method: "Ruby"},
+	"s":          {Method: "S"},
+	"samp":       {Method: "Samp"},
+	"script":     {Method: "Script", Block: true},
+	"search":     {Method: "Search", Block: true},
+	"section":    {Method: "Section", Block: true},
+	"select":     {Method: "Select", Block: true},
+	"slot":       {Method: "Slot"},
+	"small":      {Method: "Small"},
+	"source":     {Method: "Source", Void: true},
+	"span":       {Method: "Span"},
+	"strong":     {Method: "Strong"},
+	"style":      {Method: "Style", Block: true},
+	"sub":        {Method: "Sub"},
+	"summary":    {Method: "Summary", Block: true},
+	"sup":        {Method: "Sup"},
+	"table":      {Method: "Table", Block: true},
+	"tbody":      {Method: "TBody", Block: true},
+	"td":         {Method: "Td", Block: true},
+	"template":   {Method: "Template", Block: true},
+	"textarea":   {Method: "TextArea", Block: true},
+	"tfoot":      {Method: "TFoot", Block: true},
+	"th":         {Method: "Th", Block: true},
+	"thead":      {Method: "THead", Block: true},
+	"time":       {Method: "Time"},
+	"title":      {Method: "Title", Block: true},
+	"tr":         {Method: "Tr", Block: true},
+	"track":      {Method: "Track", Void: true},
+	"u":          {Method: "U"},
+	"ul":         {Method: "Ul", Block: true},
+	"var":        {Method: "Var"},
+	"video":      {Method: "Video"},
+	"wbr":        {Method: "Wbr", Void: true},
+}
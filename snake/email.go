@@ -0,0 +1,355 @@
+package snake
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Something in an email that many email clients don't support, with the
+// path of the element it was found at, if any.
+type EmailWarning struct {
+	Message string
+	Path    string
+}
+
+func (w EmailWarning) String() string {
+	if w.Path == "" {
+		return w.Message
+	}
+
+	return w.Message + " at " + w.Path
+}
+
+// One property of a CSS rule.
+type cssDeclaration struct {
+	property  string
+	value     string
+	important bool
+}
+
+// A rule that can be inlined.
+type cssRule struct {
+	selector     *Selector
+	declarations []cssDeclaration
+
+	// Position in the stylesheet, for breaking specificity ties.
+	order int
+}
+
+// Elements that many email clients drop or don't show.
+var unsupportedEmailElements = map[string]string{
+	"script": "scripts are removed by email clients",
+	"form":   "forms don't work in many email clients",
+	"input":  "form fields don't work in many email clients",
+	"video":  "video isn't supported by most email clients",
+	"audio":  "audio isn't supported by most email clients",
+	"iframe": "iframes are removed by email clients",
+	"object": "embedded objects are removed by email clients",
+	"embed":  "embedded objects are removed by email clients",
+	"svg":    "inline SVG isn't supported by many email clients",
+}
+
+// CSS properties that many email clients ignore.
+var unsupportedEmailProperties = map[string]bool{
+	"animation":  true,
+	"position":   true,
+	"transform":  true,
+	"transition": true,
+}
+
+// A small CSS stylesheet for emails. Its rules are inlined as style
+// attributes, since email clients ignore <style> blocks and stylesheet
+// links. Rules that can't be inlined, such as @media queries and :hover,
+// are kept in a <style> element for the clients that support them.
+type Stylesheet struct {
+	rules []cssRule
+
+	// CSS that can't be inlined.
+	kept []string
+
+	// Problems found while parsing.
+	warnings []EmailWarning
+}
+
+// Parse a stylesheet. Selectors are those supported by CompileSelector().
+func ParseStylesheet(css string) (*Stylesheet, error) {
+	s := &Stylesheet{}
+	css = stripCssComments(css)
+
+	for {
+		css = strings.TrimSpace(css)
+		if css == "" {
+			break
+		}
+
+		if strings.HasPrefix(css, "@") {
+			brace := strings.IndexByte(css, '{')
+			semicolon := strings.IndexByte(css, ';')
+			if semicolon >= 0 && (brace < 0 || semicolon < brace) {
+				// A statement like @import.
+				s.warnings = append(s.warnings, EmailWarning{
+					Message: fmt.Sprintf("%s isn't supported in email", strings.TrimSpace(css[:semicolon])),
+				})
+				css = css[semicolon+1:]
+				continue
+			}
+			if brace < 0 {
+				return nil, fmt.Errorf("unterminated %s", css)
+			}
+
+			end := matchingBrace(css, brace)
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed block in %s", css[:brace])
+			}
+			s.kept = append(s.kept, css[:end+1])
+			s.warnings = append(s.warnings, EmailWarning{
+				Message: fmt.Sprintf("%s can't be inlined and is ignored by some email clients",
+					strings.TrimSpace(css[:brace])),
+			})
+			css = css[end+1:]
+			continue
+		}
+
+		brace := strings.IndexByte(css, '{')
+		end := strings.IndexByte(css, '}')
+		if brace < 0 || end < brace {
+			return nil, fmt.Errorf("expected a rule at %q", css)
+		}
+		selectorText := strings.TrimSpace(css[:brace])
+		body := css[brace+1 : end]
+		css = css[end+1:]
+
+		selector, err := CompileSelector(selectorText)
+		if err != nil {
+			// Pseudo-classes like :hover only work from a <style> element.
+			s.kept = append(s.kept, selectorText+" {"+body+"}")
+			s.warnings = append(s.warnings, EmailWarning{
+				Message: fmt.Sprintf("%q can't be inlined and is ignored by some email clients", selectorText),
+			})
+			continue
+		}
+
+		declarations := parseDeclarations(body)
+		for _, d := range declarations {
+			if s.checkProperty(d.property, d.value) != "" {
+				s.warnings = append(s.warnings, EmailWarning{Message: s.checkProperty(d.property, d.value)})
+			}
+		}
+		s.rules = append(s.rules, cssRule{
+			selector:     selector,
+			declarations: declarations,
+			order:        len(s.rules),
+		})
+	}
+
+	return s, nil
+}
+
+// Return a warning about the declaration, or "".
+func (s *Stylesheet) checkProperty(property, value string) string {
+	switch {
+	case unsupportedEmailProperties[property]:
+		return fmt.Sprintf("the %q property is ignored by many email clients", property)
+	case property == "display" && (value == "flex" || value == "grid"):
+		return fmt.Sprintf("\"display: %s\" is ignored by many email clients", value)
+	case strings.Contains(value, "var("):
+		return "CSS variables are ignored by many email clients"
+	}
+
+	return ""
+}
+
+// Remove /* comments */.
+func stripCssComments(css string) string {
+	for {
+		start := strings.Index(css, "/*")
+		if start < 0 {
+			return css
+		}
+		end := strings.Index(css[start+2:], "*/")
+		if end < 0 {
+			return css[:start]
+		}
+		css = css[:start] + " " + css[start+2+end+2:]
+	}
+}
+
+// Return the index of the brace that closes the one at open, or -1.
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// Parse "color: red; margin: 0 !important".
+func parseDeclarations(body string) []cssDeclaration {
+	var declarations []cssDeclaration
+
+	for _, part := range strings.Split(body, ";") {
+		property, value, ok := strings.Cut(part, ":")
+		if !ok {
+			continue
+		}
+		property = strings.ToLower(strings.TrimSpace(property))
+		value = strings.TrimSpace(value)
+
+		important := false
+		if i := strings.Index(strings.ToLower(value), "!important"); i >= 0 {
+			important = true
+			value = strings.TrimSpace(value[:i])
+		}
+
+		if property != "" && value != "" {
+			declarations = append(declarations, cssDeclaration{property, value, important})
+		}
+	}
+
+	return declarations
+}
+
+// A declaration that applies to an element, for sorting by the cascade.
+type appliedDeclaration struct {
+	cssDeclaration
+	specificity int
+	order       int
+}
+
+// Inline the stylesheet's rules into the tree as style attributes, add a
+// <style> element to the <head> for rules that can't be inlined, and return
+// warnings about things in the stylesheet and the tree that email clients
+// don't support. An element's own style attribute wins over the rules,
+// except for !important ones.
+func (s *Stylesheet) Inline(root *Node) []EmailWarning {
+	warnings := append([]EmailWarning(nil), s.warnings...)
+
+	root.Walk(func(n *Node) bool {
+		if n.Type != ElementNode {
+			return true
+		}
+
+		if message, ok := unsupportedEmailElements[n.Tag]; ok {
+			warnings = append(warnings, EmailWarning{Message: message, Path: nodePath(n)})
+		}
+		if rel, _ := n.Attr("rel"); n.Tag == "link" && rel == "stylesheet" {
+			warnings = append(warnings, EmailWarning{
+				Message: "stylesheet links are ignored by email clients", Path: nodePath(n)})
+		}
+
+		warnings = append(warnings, s.inlineElement(n)...)
+		return n.Tag != "svg"
+	})
+
+	if len(s.kept) > 0 {
+		style := NewElement("style")
+		style.AppendChild(&Node{Type: RawNode, Text: strings.Join(s.kept, "\n")})
+
+		if head := root.FindByTag("head"); len(head) > 0 {
+			head[0].AppendChild(style)
+		} else {
+			root.PrependChild(style)
+		}
+	}
+
+	return warnings
+}
+
+// Set the element's style attribute from the rules that match it, and
+// return warnings about its own style.
+func (s *Stylesheet) inlineElement(n *Node) []EmailWarning {
+	var warnings []EmailWarning
+	var applied []appliedDeclaration
+	for _, rule := range s.rules {
+		if specificity, ok := rule.selector.specificity(n); ok {
+			for _, d := range rule.declarations {
+				applied = append(applied, appliedDeclaration{d, specificity, rule.order})
+			}
+		}
+	}
+
+	// The element's own style goes after all rules but before !important
+	// ones. It's dropped if it was untrusted and fails sanitizing.
+	var own []cssDeclaration
+	styleIndex := -1
+	for i, a := range n.attrs {
+		if a.name == "style" {
+			if a.trusted || sanitizeStyle(a.value) == a.value {
+				own = parseDeclarations(a.value)
+			}
+			styleIndex = i
+			break
+		}
+	}
+	for _, d := range own {
+		if message := s.checkProperty(d.property, d.value); message != "" {
+			warnings = append(warnings, EmailWarning{Message: message, Path: nodePath(n)})
+		}
+		applied = append(applied, appliedDeclaration{d, 1 << 30, 0})
+	}
+
+	if len(applied) == 0 || (styleIndex >= 0 && len(own) == len(applied)) {
+		// Nothing to inline.
+		return warnings
+	}
+	if styleIndex >= 0 {
+		n.attrs = append(n.attrs[:styleIndex:styleIndex], n.attrs[styleIndex+1:]...)
+	}
+
+	sort.SliceStable(applied, func(i, j int) bool {
+		a, b := applied[i], applied[j]
+		if a.important != b.important {
+			return b.important
+		}
+		if a.specificity != b.specificity {
+			return a.specificity < b.specificity
+		}
+		return a.order < b.order
+	})
+
+	// Each property is written where it last appears, so that a winning
+	// shorthand like "margin" comes after the longhands it overrides.
+	last := make(map[string]int)
+	for i, d := range applied {
+		last[d.property] = i
+	}
+	var declarations []string
+	for i, d := range applied {
+		if last[d.property] == i {
+			declarations = append(declarations, d.property+": "+d.value)
+		}
+	}
+
+	// The stylesheet is ours, so it's not sanitized.
+	n.attrs = append(n.attrs, Trust(Style(strings.Join(declarations, "; "))))
+	return warnings
+}
+
+// Render the component for email: the stylesheet's rules are inlined as
+// style attributes and the result is written to w. Returns warnings about
+// things that email clients don't support. The returned error is for
+// rendering problems, like Close().
+func RenderEmail(w io.Writer, c Component, s *Stylesheet, options ...Option) ([]EmailWarning, error) {
+	h := NewTree(options...)
+	h.Render(c)
+	err := h.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	root := h.Tree()
+	warnings := s.Inline(root)
+
+	return warnings, root.Serialize(w, options...)
+}
@@ -0,0 +1,190 @@
+package snake
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+// Written in place of a URL or style that failed sanitizing.
+const (
+	unsafeUrl   = "about:invalid#zSnakez"
+	unsafeStyle = "zSnakez"
+)
+
+// Attributes whose values are URLs.
+var urlAttrs = map[string]bool{
+	"action":     true,
+	"background": true,
+	"cite":       true,
+	"data":       true,
+	"formaction": true,
+	"href":       true,
+	"icon":       true,
+	"longdesc":   true,
+	"manifest":   true,
+	"poster":     true,
+	"src":        true,
+	"usemap":     true,
+	"xlink:href": true,
+}
+
+// URL schemes allowed in URL attributes. Relative URLs are always allowed.
+var safeSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+	"tel":    true,
+}
+
+// Mark an attribute as trusted, so that its value is written without
+// context-specific sanitizing. It's still HTML-escaped. Use this for
+// javascript: URLs, event handler code, and styles that you wrote yourself:
+//
+//	h.Button(Trust(OnClick("save()"))).Content("Save")
+func Trust(a attr) attr {
+	a.trusted = true
+	return a
+}
+
+// Return whether the attribute's value is sanitized unless it's marked with
+// Trust(): URLs, event handlers, and styles.
+func IsSanitizedAttr(name string) bool {
+	return urlAttrs[name] || eventHandlerAttrs[name] || name == "style"
+}
+
+// Return the attribute's value escaped for its context.
+func escapeAttr(a attr) string {
+	value := a.value
+
+	if !a.trusted {
+		switch {
+		case urlAttrs[a.name]:
+			value = sanitizeUrl(value)
+		case a.name == "style":
+			value = sanitizeStyle(value)
+		}
+	}
+
+	return html.EscapeString(value)
+}
+
+// Return the text escaped for the element it's in.
+func (h *HtmlResponse) escapeText(s string) string {
+	back := h.stack.Back()
+	if back != nil && !h.xml {
+		switch back.Value.(*element).tag {
+		case "script":
+			return escapeJsString(s)
+		case "style":
+			return escapeCssString(s)
+		}
+	}
+
+	return html.EscapeString(s)
+}
+
+// Return the URL if it's relative or has a safe scheme, otherwise a harmless
+// placeholder.
+func sanitizeUrl(url string) string {
+	trimmed := strings.TrimSpace(url)
+
+	// A colon before any slash, question mark, or hash means there's a scheme.
+	i := strings.IndexAny(trimmed, ":/?#")
+	if i >= 0 && trimmed[i] == ':' {
+		scheme := strings.ToLower(trimmed[:i])
+		if !safeSchemes[scheme] {
+			return unsafeUrl
+		}
+	}
+
+	return url
+}
+
+// Return the inline style if it can't run code or load resources, otherwise
+// a harmless placeholder.
+func sanitizeStyle(style string) string {
+	lower := strings.ToLower(style)
+
+	if strings.ContainsAny(lower, `<>{}\`) ||
+		strings.Contains(lower, "expression") ||
+		strings.Contains(lower, "javascript:") ||
+		strings.Contains(lower, "url(") ||
+		strings.Contains(lower, "@import") {
+
+		return unsafeStyle
+	}
+
+	return style
+}
+
+// Escape the string for use inside a JavaScript string literal, in either
+// quote style, in a <script> element.
+func escapeJsString(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\'', '"', '`', '<', '>', '&', '=', '/', '\u2028', '\u2029':
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			if r < ' ' {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// Escape the string for use inside a CSS string or identifier in a <style>
+// element. Anything other than letters, digits, spaces, and a few harmless
+// punctuation characters becomes a hex escape.
+func escapeCssString(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') ||
+			r == ' ' || r == '-' || r == '_' || r == '.' || r == ',' || r >= utf8.RuneSelf {
+
+			b.WriteRune(r)
+		} else {
+			// The trailing space ends the escape and is eaten by the CSS parser.
+			fmt.Fprintf(&b, `\%X `, r)
+		}
+	}
+
+	return b.String()
+}
+
+// Escape a CSS property value for a style attribute. Characters that could
+// end the declaration or the attribute become hex escapes; everything else
+// is kept so that values like "#fff" and "1px solid" keep their meaning.
+func escapeCssValue(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		switch {
+		case r == ';' || r == '{' || r == '}' || r == '<' || r == '>' || r == '\\' ||
+			r == '"' || r == '\'' || r == '`' || r < ' ' || r == 0x7F:
+
+			// The trailing space ends the escape and is eaten by the CSS parser.
+			fmt.Fprintf(&b, `\%X `, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,469 @@
+// Code generated by gen_elements.go from spec/foreign_elements.txt; DO NOT EDIT.
+
+package snake
+
+// Opens <svg>. Close it with Svg_().
+func (h *HtmlResponse) Svg(attrs ...attr) *HtmlResponse {
+	return h.openTag("svg", attrs)
+}
+
+// Closes <svg>.
+func (h *HtmlResponse) Svg_() *HtmlResponse {
+	return h.closeTag("svg")
+}
+
+// Opens <a>. Close it with SvgA_().
+func (h *HtmlResponse) SvgA(attrs ...attr) *HtmlResponse {
+	return h.openTag("a", attrs)
+}
+
+// Closes <a>.
+func (h *HtmlResponse) SvgA_() *HtmlResponse {
+	return h.closeTag("a")
+}
+
+// Writes a self-closing <circle/>.
+func (h *HtmlResponse) SvgCircle(attrs ...attr) *HtmlResponse {
+	return h.EmptyElement("circle", attrs...)
+}
+
+// Opens <clipPath>. Close it with SvgClipPath_().
+func (h *HtmlResponse) SvgClipPath(attrs ...attr) *HtmlResponse {
+	return h.openTag("clipPath", attrs)
+}
+
+// Closes <clipPath>.
+func (h *HtmlResponse) SvgClipPath_() *HtmlResponse {
+	return h.closeTag("clipPath")
+}
+
+// Opens <defs>. Close it with SvgDefs_().
+func (h *HtmlResponse) SvgDefs(attrs ...attr) *HtmlResponse {
+	return h.openTag("defs", attrs)
+}
+
+// Closes <defs>.
+func (h *HtmlResponse) SvgDefs_() *HtmlResponse {
+	return h.closeTag("defs")
+}
+
+// Opens <desc>. Close it with SvgDesc_().
+func (h *HtmlResponse) SvgDesc(attrs ...attr) *HtmlResponse {
+	return h.openTag("desc", attrs)
+}
+
+// Closes <desc>.
+func (h *HtmlResponse) SvgDesc_() *HtmlResponse {
+	return h.closeTag("desc")
+}
+
+// Writes a self-closing <ellipse/>.
+func (h *HtmlResponse) SvgEllipse(attrs ...attr) *HtmlResponse {
+	return h.EmptyElement("ellipse", attrs...)
+}
+
+// Opens <foreignObject>. Close it with SvgForeignObject_().
+func (h *HtmlResponse) SvgForeignObject(attrs ...attr) *HtmlResponse {
+	return h.openTag("foreignObject", attrs)
+}
+
+// Closes <foreignObject>.
+func (h *HtmlResponse) SvgForeignObject_() *HtmlResponse {
+	return h.closeTag("foreignObject")
+}
+
+// Opens <g>. Close it with SvgG_().
+func (h *HtmlResponse) SvgG(attrs ...attr) *HtmlResponse {
+	return h.openTag("g", attrs)
+}
+
+// Closes <g>.
+func (h *HtmlResponse) SvgG_() *HtmlResponse {
+	return h.closeTag("g")
+}
+
+// Writes a self-closing <image/>.
+func (h *HtmlResponse) SvgImage(attrs ...attr) *HtmlResponse {
+	return h.EmptyElement("image", attrs...)
+}
+
+// Writes a self-closing <line/>.
+func (h *HtmlResponse) SvgLine(attrs ...attr) *HtmlResponse {
+	return h.EmptyElement("line", attrs...)
+}
+
+// Opens <linearGradient>. Close it with SvgLinearGradient_().
+func (h *HtmlResponse) SvgLinearGradient(attrs ...attr) *HtmlResponse {
+	return h.openTag("linearGradient", attrs)
+}
+
+// Closes <linearGradient>.
+func (h *HtmlResponse) SvgLinearGradient_() *HtmlResponse {
+	return h.closeTag("linearGradient")
+}
+
+// Opens <marker>. Close it with SvgMarker_().
+func (h *HtmlResponse) SvgMarker(attrs ...attr) *HtmlResponse {
+	return h.openTag("marker", attrs)
+}
+
+// Closes <marker>.
+func (h *HtmlResponse) SvgMarker_() *HtmlResponse {
+	return h.closeTag("marker")
+}
+
+// Opens <mask>. Close it with SvgMask_().
+func (h *HtmlResponse) SvgMask(attrs ...attr) *HtmlResponse {
+	return h.openTag("mask", attrs)
+}
+
+// Closes <mask>.
+func (h *HtmlResponse) SvgMask_() *HtmlResponse {
+	return h.closeTag("mask")
+}
+
+// Writes a self-closing <path/>.
+func (h *HtmlResponse) SvgPath(attrs ...attr) *HtmlResponse {
+	return h.EmptyElement("path", attrs...)
+}
+
+// Opens <pattern>. Close it with SvgPattern_().
+func (h *HtmlResponse) SvgPattern(attrs ...attr) *HtmlResponse {
+	return h.openTag("pattern", attrs)
+}
+
+// Closes <pattern>.
+func (h *HtmlResponse) SvgPattern_() *HtmlResponse {
+	return h.closeTag("pattern")
+}
+
+// Writes a self-closing <polygon/>.
+func (h *HtmlResponse) SvgPolygon(attrs ...attr) *HtmlResponse {
+	return h.EmptyElement("polygon", attrs...)
+}
+
+// Writes a self-closing <polyline/>.
+func (h *HtmlResponse) SvgPolyline(attrs ...attr) *HtmlResponse {
+	return h.EmptyElement("polyline", attrs...)
+}
+
+// Opens <radialGradient>. Close it with SvgRadialGradient_().
+func (h *HtmlResponse) SvgRadialGradient(attrs ...attr) *HtmlResponse {
+	return h.openTag("radialGradient", attrs)
+}
+
+// Closes <radialGradient>.
+func (h *HtmlResponse) SvgRadialGradient_() *HtmlResponse {
+	return h.closeTag("radialGradient")
+}
+
+// Writes a self-closing <rect/>.
+func (h *HtmlResponse) SvgRect(attrs ...attr) *HtmlResponse {
+	return h.EmptyElement("rect", attrs...)
+}
+
+// Writes a self-closing <stop/>.
+func (h *HtmlResponse) SvgStop(attrs ...attr) *HtmlResponse {
+	return h.EmptyElement("stop", attrs...)
+}
+
+// Opens <style>. Close it with SvgStyle_().
+func (h *HtmlResponse) SvgStyle(attrs ...attr) *HtmlResponse {
+	return h.openTag("style", attrs)
+}
+
+// Closes <style>.
+func (h *HtmlResponse) SvgStyle_() *HtmlResponse {
+	return h.closeTag("style")
+}
+
+// Opens <symbol>. Close it with SvgSymbol_().
+func (h *HtmlResponse) SvgSymbol(attrs ...attr) *HtmlResponse {
+	return h.openTag("symbol", attrs)
+}
+
+// Closes <symbol>.
+func (h *HtmlResponse) SvgSymbol_() *HtmlResponse {
+	return h.closeTag("symbol")
+}
+
+// Opens <text>. Close it with SvgText_().
+func (h *HtmlResponse) SvgText(attrs ...attr) *HtmlResponse {
+	return h.openTag("text", attrs)
+}
+
+// Closes <text>.
+func (h *HtmlResponse) SvgText_() *HtmlResponse {
+	return h.closeTag("text")
+}
+
+// Opens <textPath>. Close it with SvgTextPath_().
+func (h *HtmlResponse) SvgTextPath(attrs ...attr) *HtmlResponse {
+	return h.openTag("textPath", attrs)
+}
+
+// Closes <textPath>.
+func (h *HtmlResponse) SvgTextPath_() *HtmlResponse {
+	return h.closeTag("textPath")
+}
+
+// Opens <title>. Close it with SvgTitle_().
+func (h *HtmlResponse) SvgTitle(attrs ...attr) *HtmlResponse {
+	return h.openTag("title", attrs)
+}
+
+// Closes <title>.
+func (h *HtmlResponse) SvgTitle_() *HtmlResponse {
+	return h.closeTag("title")
+}
+
+// Opens <tspan>. Close it with SvgTSpan_().
+func (h *HtmlResponse) SvgTSpan(attrs ...attr) *HtmlResponse {
+	return h.openTag("tspan", attrs)
+}
+
+// Closes <tspan>.
+func (h *HtmlResponse) SvgTSpan_() *HtmlResponse {
+	return h.closeTag("tspan")
+}
+
+// Writes a self-closing <use/>.
+func (h *HtmlResponse) SvgUse(attrs ...attr) *HtmlResponse {
+	return h.EmptyElement("use", attrs...)
+}
+
+// Opens <math>. Close it with Math_().
+func (h *HtmlResponse) Math(attrs ...attr) *HtmlResponse {
+	return h.openTag("math", attrs)
+}
+
+// Closes <math>.
+func (h *HtmlResponse) Math_() *HtmlResponse {
+	return h.closeTag("math")
+}
+
+// Opens <mfrac>. Close it with MFrac_().
+func (h *HtmlResponse) MFrac(attrs ...attr) *HtmlResponse {
+	return h.openTag("mfrac", attrs)
+}
+
+// Closes <mfrac>.
+func (h *HtmlResponse) MFrac_() *HtmlResponse {
+	return h.closeTag("mfrac")
+}
+
+// Opens <mi>. Close it with Mi_().
+func (h *HtmlResponse) Mi(attrs ...attr) *HtmlResponse {
+	return h.openTag("mi", attrs)
+}
+
+// Closes <mi>.
+func (h *HtmlResponse) Mi_() *HtmlResponse {
+	return h.closeTag("mi")
+}
+
+// Opens <mn>. Close it with Mn_().
+func (h *HtmlResponse) Mn(attrs ...attr) *HtmlResponse {
+	return h.openTag("mn", attrs)
+}
+
+// Closes <mn>.
+func (h *HtmlResponse) Mn_() *HtmlResponse {
+	return h.closeTag("mn")
+}
+
+// Opens <mo>. Close it with Mo_().
+func (h *HtmlResponse) Mo(attrs ...attr) *HtmlResponse {
+	return h.openTag("mo", attrs)
+}
+
+// Closes <mo>.
+func (h *HtmlResponse) Mo_() *HtmlResponse {
+	return h.closeTag("mo")
+}
+
+// Opens <mover>. Close it with MOver_().
+func (h *HtmlResponse) MOver(attrs ...attr) *HtmlResponse {
+	return h.openTag("mover", attrs)
+}
+
+// Closes <mover>.
+func (h *HtmlResponse) MOver_() *HtmlResponse {
+	return h.closeTag("mover")
+}
+
+// Opens <mroot>. Close it with MRoot_().
+func (h *HtmlResponse) MRoot(attrs ...attr) *HtmlResponse {
+	return h.openTag("mroot", attrs)
+}
+
+// Closes <mroot>.
+func (h *HtmlResponse) MRoot_() *HtmlResponse {
+	return h.closeTag("mroot")
+}
+
+// Opens <mrow>. Close it with MRow_().
+func (h *HtmlResponse) MRow(attrs ...attr) *HtmlResponse {
+	return h.openTag("mrow", attrs)
+}
+
+// Closes <mrow>.
+func (h *HtmlResponse) MRow_() *HtmlResponse {
+	return h.closeTag("mrow")
+}
+
+// Writes a self-closing <mspace/>.
+func (h *HtmlResponse) MSpace(attrs ...attr) *HtmlResponse {
+	return h.EmptyElement("mspace", attrs...)
+}
+
+// Opens <msqrt>. Close it with MSqrt_().
+func (h *HtmlResponse) MSqrt(attrs ...attr) *HtmlResponse {
+	return h.openTag("msqrt", attrs)
+}
+
+// Closes <msqrt>.
+func (h *HtmlResponse) MSqrt_() *HtmlResponse {
+	return h.closeTag("msqrt")
+}
+
+// Opens <msub>. Close it with MSub_().
+func (h *HtmlResponse) MSub(attrs ...attr) *HtmlResponse {
+	return h.openTag("msub", attrs)
+}
+
+// Closes <msub>.
+func (h *HtmlResponse) MSub_() *HtmlResponse {
+	return h.closeTag("msub")
+}
+
+// Opens <msubsup>. Close it with MSubSup_().
+func (h *HtmlResponse) MSubSup(attrs ...attr) *HtmlResponse {
+	return h.openTag("msubsup", attrs)
+}
+
+// Closes <msubsup>.
+func (h *HtmlResponse) MSubSup_() *HtmlResponse {
+	return h.closeTag("msubsup")
+}
+
+// Opens <msup>. Close it with MSup_().
+func (h *HtmlResponse) MSup(attrs ...attr) *HtmlResponse {
+	return h.openTag("msup", attrs)
+}
+
+// Closes <msup>.
+func (h *HtmlResponse) MSup_() *HtmlResponse {
+	return h.closeTag("msup")
+}
+
+// Opens <mtable>. Close it with MTable_().
+func (h *HtmlResponse) MTable(attrs ...attr) *HtmlResponse {
+	return h.openTag("mtable", attrs)
+}
+
+// Closes <mtable>.
+func (h *HtmlResponse) MTable_() *HtmlResponse {
+	return h.closeTag("mtable")
+}
+
+// Opens <mtd>. Close it with MTd_().
+func (h *HtmlResponse) MTd(attrs ...attr) *HtmlResponse {
+	return h.openTag("mtd", attrs)
+}
+
+// Closes <mtd>.
+func (h *HtmlResponse) MTd_() *HtmlResponse {
+	return h.closeTag("mtd")
+}
+
+// Opens <mtext>. Close it with MText_().
+func (h *HtmlResponse) MText(attrs ...attr) *HtmlResponse {
+	return h.openTag("mtext", attrs)
+}
+
+// Closes <mtext>.
+func (h *HtmlResponse) MText_() *HtmlResponse {
+	return h.closeTag("mtext")
+}
+
+// Opens <mtr>. Close it with MTr_().
+func (h *HtmlResponse) MTr(attrs ...attr) *HtmlResponse {
+	return h.openTag("mtr", attrs)
+}
+
+// Closes <mtr>.
+func (h *HtmlResponse) MTr_() *HtmlResponse {
+	return h.closeTag("mtr")
+}
+
+// Opens <munder>. Close it with MUnder_().
+func (h *HtmlResponse) MUnder(attrs ...attr) *HtmlResponse {
+	return h.openTag("munder", attrs)
+}
+
+// Closes <munder>.
+func (h *HtmlResponse) MUnder_() *HtmlResponse {
+	return h.closeTag("munder")
+}
+
+// Opens <munderover>. Close it with MUnderOver_().
+func (h *HtmlResponse) MUnderOver(attrs ...attr) *HtmlResponse {
+	return h.openTag("munderover", attrs)
+}
+
+// Closes <munderover>.
+func (h *HtmlResponse) MUnderOver_() *HtmlResponse {
+	return h.closeTag("munderover")
+}
+
+// Methods for SVG and MathML elements, by tag.
+var foreignElementInfos = map[string]ElementInfo{
+	"svg":            {Method: "Svg"},
+	"a":              {Method: "SvgA"},
+	"circle":         {Method: "SvgCircle", SelfClosing: true},
+	"clipPath":       {Method: "SvgClipPath"},
+	"defs":           {Method: "SvgDefs"},
+	"desc":           {Method: "SvgDesc"},
+	"ellipse":        {Method: "SvgEllipse", SelfClosing: true},
+	"foreignObject":  {Method: "SvgForeignObject"},
+	"g":              {Method: "SvgG"},
+	"image":          {Method: "SvgImage", SelfClosing: true},
+	"line":           {Method: "SvgLine", SelfClosing: true},
+	"linearGradient": {Method: "SvgLinearGradient"},
+	"marker":         {Method: "SvgMarker"},
+	"mask":           {Method: "SvgMask"},
+	"path":           {Method: "SvgPath", SelfClosing: true},
+	"pattern":        {Method: "SvgPattern"},
+	"polygon":        {Method: "SvgPolygon", SelfClosing: true},
+	"polyline":       {Method: "SvgPolyline", SelfClosing: true},
+	"radialGradient": {Method: "SvgRadialGradient"},
+	"rect":           {Method: "SvgRect", SelfClosing: true},
+	"stop":           {Method: "SvgStop", SelfClosing: true},
+	"style":          {Method: "SvgStyle"},
+	"symbol":         {Method: "SvgSymbol"},
+	"text":           {Method: "SvgText"},
+	"textPath":       {Method: "SvgTextPath"},
+	"title":          {Method: "SvgTitle"},
+	"tspan":          {Method: "SvgTSpan"},
+	"use":            {Method: "SvgUse", SelfClosing: true},
+	"math":           {Method: "Math"},
+	"mfrac":          {Method: "MFrac"},
+	"mi":             {Method: "Mi"},
+	"mn":             {Method: "Mn"},
+	"mo":             {Method: "Mo"},
+	"mover":          {Method: "MOver"},
+	"mroot":          {Method: "MRoot"},
+	"mrow":           {Method: "MRow"},
+	"mspace":         {Method: "MSpace", SelfClosing: true},
+	"msqrt":          {Method: "MSqrt"},
+	"msub":           {Method: "MSub"},
+	"msubsup":        {Method: "MSubSup"},
+	"msup":           {Method: "MSup"},
+	"mtable":         {Method: "MTable"},
+	"mtd":            {Method: "MTd"},
+	"mtext":          {Method: "MText"},
+	"mtr":            {Method: "MTr"},
+	"munder":         {Method: "MUnder"},
+	"munderover":     {Method: "MUnderOver"},
+}
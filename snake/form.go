@@ -0,0 +1,226 @@
+package snake
+
+import (
+	"github.com/lkesteloot/goutil/dbutil"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Validation messages for a form, keyed by field name.
+type FieldErrors map[string]string
+
+// One choice of an IdField select.
+type SelectOption struct {
+	Id    dbutil.IdField
+	Label string
+}
+
+// Writes labeled form fields for dbutil values. Each field looks like:
+//
+//	<div class="field has-error">
+//	  <label for="order-price">Price</label>
+//	  <input type="text" id="order-price" name="price" value="$1.50">
+//	  <span class="error">Not a valid amount</span>
+//	</div>
+//
+// with the "has-error" class and the error message only present if errors
+// has an entry for the field. The ids start with the form's name ("order"
+// here) so that they're unique when a page has several forms. Read the
+// submitted values back with FormValues.
+type FormBuilder struct {
+	h      *HtmlResponse
+	name   string
+	errors FieldErrors
+}
+
+// Make a builder for the named form, writing to h. The errors may be nil.
+func NewFormBuilder(h *HtmlResponse, name string, errors FieldErrors) *FormBuilder {
+	return &FormBuilder{
+		h:      h,
+		name:   name,
+		errors: errors,
+	}
+}
+
+// Return the id attribute value for the field's input.
+func (f *FormBuilder) fieldId(name string) string {
+	return f.name + "-" + name
+}
+
+// Open the field's wrapper and write its label.
+func (f *FormBuilder) startField(name, label string) {
+	class := "field"
+	if f.errors[name] != "" {
+		class += " has-error"
+	}
+
+	f.h.Div(Class(class)).
+		Label(For(f.fieldId(name))).Content(label)
+}
+
+// Write the field's error, if any, and close its wrapper.
+func (f *FormBuilder) endField(name string) {
+	if message := f.errors[name]; message != "" {
+		f.h.Span(Class("error")).Content(message)
+	}
+	f.h.Div_()
+}
+
+// Write a text input.
+func (f *FormBuilder) TextField(name, label, value string) *FormBuilder {
+	f.startField(name, label)
+	f.h.Input(Type("text"), Id(f.fieldId(name)), Name(name), Value(value))
+	f.endField(name)
+
+	return f
+}
+
+// Write a text area.
+func (f *FormBuilder) TextAreaField(name, label, value string) *FormBuilder {
+	f.startField(name, label)
+	f.h.TextArea(Id(f.fieldId(name)), Name(name)).Content(value)
+	f.endField(name)
+
+	return f
+}
+
+// Write a text input showing the money as "$1.50".
+func (f *FormBuilder) MoneyField(name, label string, value dbutil.Money) *FormBuilder {
+	return f.TextField(name, label, value.ToTextField())
+}
+
+// Write a text input showing the percentage as "12.5%".
+func (f *FormBuilder) PercentField(name, label string, value dbutil.Percent) *FormBuilder {
+	return f.TextField(name, label, value.ToTextField())
+}
+
+// Write a checkbox, checked if the boolean is true.
+func (f *FormBuilder) BooleanField(name, label string, value dbutil.Boolean) *FormBuilder {
+	attrs := []attr{Type("checkbox"), Id(f.fieldId(name)), Name(name), Value("true")}
+	if !value.IsNull && value.Value {
+		attrs = append(attrs, Checked())
+	}
+
+	f.startField(name, label)
+	f.h.Input(attrs...)
+	f.endField(name)
+
+	return f
+}
+
+// Write a select of the options, with the one matching value selected. If
+// allowNull is true or the value is null (0), the select starts with a
+// blank option for null.
+func (f *FormBuilder) IdField(name, label string, value dbutil.IdField, options []SelectOption,
+	allowNull bool) *FormBuilder {
+
+	f.startField(name, label)
+	f.h.Select(Id(f.fieldId(name)), Name(name))
+	if allowNull || value == 0 {
+		f.h.Option(Value(""), AttrIf(value == 0, Selected())).Option_()
+	}
+	for _, option := range options {
+		attrs := []attr{Value(option.Id.ToTextField())}
+		if option.Id == value {
+			attrs = append(attrs, Selected())
+		}
+		f.h.Option(attrs...).Content(option.Label)
+	}
+	f.h.Select_()
+	f.endField(name)
+
+	return f
+}
+
+// Write a submit button.
+func (f *FormBuilder) Submit(label string) *FormBuilder {
+	f.h.Button(Type("submit")).Content(label)
+
+	return f
+}
+
+var (
+	// Matched against trimmed fields, and only when they're not empty.
+	moneyPattern   = regexp.MustCompile(`^\$? *([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+	percentPattern = regexp.MustCompile(`^-?([0-9]+(\.[0-9]*)?|\.[0-9]+) *%?$`)
+	idPattern      = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// Reads submitted form fields back into dbutil values, using the Parse
+// function that matches each field type, and collects validation errors to
+// pass back to NewFormBuilder if the form must be shown again.
+type FormValues struct {
+	r      *http.Request
+	Errors FieldErrors
+}
+
+func NewFormValues(r *http.Request) *FormValues {
+	return &FormValues{
+		r:      r,
+		Errors: make(FieldErrors),
+	}
+}
+
+// Record an error for the field. Only the first error per field is kept.
+func (v *FormValues) AddError(name, message string) {
+	if v.Errors[name] == "" {
+		v.Errors[name] = message
+	}
+}
+
+// Return whether no errors have been recorded.
+func (v *FormValues) Valid() bool {
+	return len(v.Errors) == 0
+}
+
+// Record an error if the field is empty.
+func (v *FormValues) Require(name, message string) *FormValues {
+	if strings.TrimSpace(v.r.FormValue(name)) == "" {
+		v.AddError(name, message)
+	}
+
+	return v
+}
+
+// Return the field as a string.
+func (v *FormValues) Text(name string) string {
+	return v.r.FormValue(name)
+}
+
+// Return the field as money. An empty field is null money.
+func (v *FormValues) Money(name string) dbutil.Money {
+	s := strings.TrimSpace(v.r.FormValue(name))
+	if s != "" && !moneyPattern.MatchString(s) {
+		v.AddError(name, "Not a valid amount")
+	}
+
+	return dbutil.ParseMoney(s)
+}
+
+// Return the field as a percentage. An empty field is a null percentage if
+// allowNull is true, otherwise zero.
+func (v *FormValues) Percent(name string, allowNull bool) dbutil.Percent {
+	s := strings.TrimSpace(v.r.FormValue(name))
+	if s != "" && !percentPattern.MatchString(s) {
+		v.AddError(name, "Not a valid percentage")
+	}
+
+	return dbutil.ParsePercent(s, allowNull)
+}
+
+// Return the checkbox field as a boolean. Unchecked boxes aren't submitted,
+// so a missing field is false.
+func (v *FormValues) Boolean(name string) dbutil.Boolean {
+	return dbutil.ParseBooleanDefaultFalse(v.r.FormValue(name))
+}
+
+// Return the select field as an IdField, or 0 (null) if it's missing.
+func (v *FormValues) IdField(name string) dbutil.IdField {
+	s := strings.TrimSpace(v.r.FormValue(name))
+	if s != "" && !idPattern.MatchString(s) {
+		v.AddError(name, "Not a valid choice")
+	}
+
+	return dbutil.ParseIdField(s, 0)
+}
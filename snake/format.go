@@ -0,0 +1,121 @@
+package snake
+
+import (
+	"strings"
+)
+
+// How the markup is laid out.
+type OutputMode int
+
+const (
+	// Everything on one line, exactly as written. This is the default.
+	OutputCompact OutputMode = iota
+
+	// Block elements on their own lines, indented by nesting depth. Inline
+	// elements and the contents of whitespace-sensitive elements like <pre>
+	// and <textarea> are left alone.
+	OutputPretty
+
+	// Runs of whitespace in text collapsed to one space, and optional closing
+	// tags (</li>, </td>, </body>, etc.) left out.
+	OutputMinified
+)
+
+const indentString = "  "
+
+// Set the output mode of the response.
+func WithOutputMode(mode OutputMode) Option {
+	return func(h *HtmlResponse) {
+		h.mode = mode
+	}
+}
+
+// Return whether we're inside an element whose contents are whitespace-sensitive.
+func (h *HtmlResponse) inPreformatted() bool {
+	for e := h.stack.Back(); e != nil; e = e.Prev() {
+		if !h.xml && preformattedElements[e.Value.(*element).tag] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Return the number of open block elements.
+func (h *HtmlResponse) blockDepth() int {
+	depth := 0
+
+	for e := h.stack.Front(); e != nil; e = e.Next() {
+		tag := e.Value.(*element).tag
+		if h.xml || blockElements[tag] || preformattedElements[tag] {
+			depth++
+		}
+	}
+
+	return depth
+}
+
+// Start a new line indented to depth, unless we're already at the start of one.
+func (h *HtmlResponse) newLine(depth int) {
+	if h.midLine {
+		h.WriteStr("\n")
+	}
+	h.WriteStr(strings.Repeat(indentString, depth))
+}
+
+// Called before an opening tag or void tag is written.
+func (h *HtmlResponse) formatBeforeTag(tag string) {
+	if h.mode != OutputPretty ||
+		(!h.xml && !blockElements[tag] && !preformattedElements[tag]) ||
+		h.inPreformatted() {
+
+		return
+	}
+
+	if back := h.stack.Back(); back != nil {
+		back.Value.(*element).hasBlockChild = true
+	}
+	h.newLine(h.blockDepth())
+}
+
+// Called before a closing tag is written. Returns whether to write it.
+func (h *HtmlResponse) formatBeforeCloseTag(tag string) bool {
+	switch h.mode {
+	case OutputPretty:
+		back := h.stack.Back()
+		if back != nil {
+			e := back.Value.(*element)
+			if e.tag == tag && e.hasBlockChild && !h.inPreformatted() {
+				h.newLine(h.blockDepth() - 1)
+			}
+		}
+	case OutputMinified:
+		return h.xml || !optionalEndTags[tag]
+	}
+
+	return true
+}
+
+// Return the text laid out for the output mode.
+func (h *HtmlResponse) formatText(s string) string {
+	if h.mode != OutputMinified || h.inPreformatted() {
+		return s
+	}
+
+	// Collapse whitespace, keeping a single space at either end if there was any.
+	collapsed := strings.Join(htmlFields(s), " ")
+	if collapsed == "" {
+		if s == "" {
+			return ""
+		}
+		return " "
+	}
+	if isSpace(s[0]) {
+		collapsed = " " + collapsed
+	}
+	if isSpace(s[len(s)-1]) {
+		collapsed += " "
+	}
+
+	return collapsed
+}
@@ -0,0 +1,249 @@
+//go:build ignore
+
+// Generates elements_gen.go, foreign_gen.go, and attrs_gen.go from the
+// tables in the spec directory. Run with "go generate" in the snake directory.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+)
+
+// One non-comment line of a spec table, split into columns.
+type specLine struct {
+	fields []string
+	lineNo int
+}
+
+// Read a whitespace-separated spec table, skipping blank lines and comments.
+// Every line must have exactly columns fields.
+func readSpec(filename string, columns int) []specLine {
+	f, err := os.Open(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	var lines []specLine
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != columns {
+			log.Fatalf("%s:%d: expected %d columns, got %d", filename, lineNo, columns, len(fields))
+		}
+		lines = append(lines, specLine{fields, lineNo})
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	return lines
+}
+
+// Format the generated source and write it to filename.
+func writeSource(filename string, buf *bytes.Buffer) {
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("%s: %s", filename, err)
+	}
+
+	err = os.WriteFile(filename, src, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Write a map[string]bool variable with the names set to true.
+func writeSet(buf *bytes.Buffer, variable, comment string, names []string) {
+	fmt.Fprintf(buf, "\n// %s\n", comment)
+	fmt.Fprintf(buf, "var %s = map[string]bool{\n", variable)
+	for _, name := range names {
+		fmt.Fprintf(buf, "\t%q: true,\n", name)
+	}
+	fmt.Fprintf(buf, "}\n")
+}
+
+// Write a map variable from "key: value" entries.
+func writeMap(buf *bytes.Buffer, variable, typ, comment string, entries []string) {
+	fmt.Fprintf(buf, "\n// %s\n", comment)
+	fmt.Fprintf(buf, "var %s = %s{\n", variable, typ)
+	for _, entry := range entries {
+		fmt.Fprintf(buf, "\t%s,\n", entry)
+	}
+	fmt.Fprintf(buf, "}\n")
+}
+
+func generateElements() {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by gen_elements.go from spec/elements.txt; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package snake\n")
+
+	seen := make(map[string]bool)
+	var voids, optionals, blocks, pres, infos []string
+	for _, line := range readSpec("spec/elements.txt", 4) {
+		name, method, kind, display := line.fields[0], line.fields[1], line.fields[2], line.fields[3]
+		if seen[method] {
+			log.Fatalf("spec/elements.txt:%d: duplicate method %s", line.lineNo, method)
+		}
+		seen[method] = true
+
+		block := ""
+		switch display {
+		case "block":
+			blocks = append(blocks, name)
+			block = ", Block: true"
+		case "pre":
+			pres = append(pres, name)
+			block = ", Block: true"
+		case "inline":
+		default:
+			log.Fatalf("spec/elements.txt:%d: unknown display %q", line.lineNo, display)
+		}
+
+		switch kind {
+		case "void":
+			voids = append(voids, name)
+			infos = append(infos, fmt.Sprintf("%q: {Method: %q, Void: true%s}", name, method, block))
+			fmt.Fprintf(&buf, "\n// Writes <%s>, which has no closing tag.\n", name)
+			fmt.Fprintf(&buf, "func (h *HtmlResponse) %s(attrs ...attr) *HtmlResponse {\n", method)
+			fmt.Fprintf(&buf, "\treturn h.singleTag(%q, attrs)\n}\n", name)
+		case "paired", "optional":
+			if kind == "optional" {
+				optionals = append(optionals, name)
+			}
+			infos = append(infos, fmt.Sprintf("%q: {Method: %q%s}", name, method, block))
+			fmt.Fprintf(&buf, "\n// Opens <%s>. Close it with %s_().\n", name, method)
+			fmt.Fprintf(&buf, "func (h *HtmlResponse) %s(attrs ...attr) *HtmlResponse {\n", method)
+			fmt.Fprintf(&buf, "\treturn h.openTag(%q, attrs)\n}\n", name)
+			fmt.Fprintf(&buf, "\n// Closes <%s>.\n", name)
+			fmt.Fprintf(&buf, "func (h *HtmlResponse) %s_() *HtmlResponse {\n", method)
+			fmt.Fprintf(&buf, "\treturn h.closeTag(%q)\n}\n", name)
+		default:
+			log.Fatalf("spec/elements.txt:%d: unknown kind %q", line.lineNo, kind)
+		}
+	}
+
+	writeSet(&buf, "voidElements", "Elements that have no closing tag.", voids)
+	writeSet(&buf, "optionalEndTags", "Elements whose closing tag may be omitted.", optionals)
+	writeSet(&buf, "blockElements", "Elements that pretty-printing puts on their own line.", blocks)
+	writeSet(&buf, "preformattedElements", "Elements whose contents are whitespace-sensitive.", pres)
+	writeMap(&buf, "elementInfos", "map[string]ElementInfo", "Methods for HTML elements, by tag.", infos)
+
+	writeSource("elements_gen.go", &buf)
+}
+
+func generateForeignElements() {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by gen_elements.go from spec/foreign_elements.txt; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package snake\n")
+
+	seen := make(map[string]bool)
+	var infos []string
+	for _, line := range readSpec("spec/foreign_elements.txt", 3) {
+		name, method, kind := line.fields[0], line.fields[1], line.fields[2]
+		if seen[method] {
+			log.Fatalf("spec/foreign_elements.txt:%d: duplicate method %s", line.lineNo, method)
+		}
+		seen[method] = true
+
+		switch kind {
+		case "empty":
+			infos = append(infos, fmt.Sprintf("%q: {Method: %q, SelfClosing: true}", name, method))
+			fmt.Fprintf(&buf, "\n// Writes a self-closing <%s/>.\n", name)
+			fmt.Fprintf(&buf, "func (h *HtmlResponse) %s(attrs ...attr) *HtmlResponse {\n", method)
+			fmt.Fprintf(&buf, "\treturn h.EmptyElement(%q, attrs...)\n}\n", name)
+		case "paired":
+			infos = append(infos, fmt.Sprintf("%q: {Method: %q}", name, method))
+			fmt.Fprintf(&buf, "\n// Opens <%s>. Close it with %s_().\n", name, method)
+			fmt.Fprintf(&buf, "func (h *HtmlResponse) %s(attrs ...attr) *HtmlResponse {\n", method)
+			fmt.Fprintf(&buf, "\treturn h.openTag(%q, attrs)\n}\n", name)
+			fmt.Fprintf(&buf, "\n// Closes <%s>.\n", name)
+			fmt.Fprintf(&buf, "func (h *HtmlResponse) %s_() *HtmlResponse {\n", method)
+			fmt.Fprintf(&buf, "\treturn h.closeTag(%q)\n}\n", name)
+		default:
+			log.Fatalf("spec/foreign_elements.txt:%d: unknown kind %q", line.lineNo, kind)
+		}
+	}
+
+	writeMap(&buf, "foreignElementInfos", "map[string]ElementInfo", "Methods for SVG and MathML elements, by tag.", infos)
+
+	writeSource("foreign_gen.go", &buf)
+}
+
+func generateAttrs() {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by gen_elements.go from spec/attributes.txt; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package snake\n")
+
+	seen := make(map[string]bool)
+	var infos, handlers []string
+	for _, line := range readSpec("spec/attributes.txt", 4) {
+		name, function, kind, elements := line.fields[0], line.fields[1], line.fields[2], line.fields[3]
+		if seen[function] {
+			log.Fatalf("spec/attributes.txt:%d: duplicate function %s", line.lineNo, function)
+		}
+		seen[function] = true
+
+		description := fmt.Sprintf("%q attribute", name)
+		switch kind {
+		case "boolean":
+			description = "boolean " + description
+		case "handler":
+			description = fmt.Sprintf("%q event handler attribute", name)
+		}
+		switch elements {
+		case "*":
+			fmt.Fprintf(&buf, "\n// Makes the global %s.\n", description)
+		case "svg:*":
+			fmt.Fprintf(&buf, "\n// Makes the %s (SVG elements).\n", description)
+		default:
+			fmt.Fprintf(&buf, "\n// Makes the %s (%s).\n", description, strings.Replace(elements, ",", ", ", -1))
+		}
+
+		switch kind {
+		case "string":
+			infos = append(infos, fmt.Sprintf("%q: {Function: %q}", name, function))
+			fmt.Fprintf(&buf, "func %s(value string) attr {\n", function)
+			fmt.Fprintf(&buf, "\treturn Attr(%q, value)\n}\n", name)
+		case "handler":
+			handlers = append(handlers, name)
+			infos = append(infos, fmt.Sprintf("%q: {Function: %q}", name, function))
+			fmt.Fprintf(&buf, "// Wrap code you wrote in Trust(); untrusted handlers are tag errors.\n")
+			fmt.Fprintf(&buf, "func %s(value string) attr {\n", function)
+			fmt.Fprintf(&buf, "\treturn Attr(%q, value)\n}\n", name)
+		case "boolean":
+			infos = append(infos, fmt.Sprintf("%q: {Function: %q, Boolean: true}", name, function))
+			fmt.Fprintf(&buf, "func %s() attr {\n", function)
+			fmt.Fprintf(&buf, "\treturn BoolAttr(%q)\n}\n", name)
+		default:
+			log.Fatalf("spec/attributes.txt:%d: unknown kind %q", line.lineNo, kind)
+		}
+	}
+
+	writeSet(&buf, "eventHandlerAttrs", "Attributes whose value is code run on an event.", handlers)
+	writeMap(&buf, "attrInfos", "map[string]AttrInfo", "Functions for attributes, by name.", infos)
+
+	writeSource("attrs_gen.go", &buf)
+}
+
+func main() {
+	generateElements()
+	generateForeignElements()
+	generateAttrs()
+}
@@ -3,225 +3,334 @@ package snake
 import (
 	"bufio"
 	"container/list"
-	"html"
+	"errors"
+	"github.com/lkesteloot/goutil/snake/i18n"
 	"io"
+	"net/http"
 )
 
-const checkTagPairing = true
+//go:generate go run gen_elements.go
 
 type HtmlResponse struct {
 	w     *bufio.Writer
+	out   io.Writer
 	stack *list.List
-}
 
-func New(w io.Writer) *HtmlResponse {
-	return &HtmlResponse{bufio.NewWriter(w), list.New()}
-}
+	// First error returned by the writer. Once set, nothing more is written.
+	err error
 
-func (h *HtmlResponse) WriteStr(s string) {
-	h.w.WriteString(s)
-}
+	// Layout of the markup, and whether the last thing written didn't end a line.
+	mode    OutputMode
+	midLine bool
 
-func (h *HtmlResponse) Flush() *HtmlResponse {
-	h.w.Flush()
-	return h
-}
+	// Whether we're writing XML rather than HTML.
+	xml bool
 
-func (h *HtmlResponse) openTag(tag string, attrs []attr) *HtmlResponse {
-	h.stack.PushBack(tag)
+	// If not nil, the tree being built instead of writing markup.
+	tree *treeBuilder
+
+	// Tag pairing checks.
+	validation Validation
+	tagErrors  []error
+
+	// Layout being rendered, if any.
+	layout *layoutState
+
+	// Fingerprinted assets for ScriptLink() and StyleLink(), if any.
+	assets *AssetManifest
+
+	// Content-Security-Policy nonce for <script> and <style>, if any.
+	nonce string
+
+	// Messages for T(), if any.
+	catalog *i18n.Catalog
+
+	// With WithLint(), the page as written, for checking at Close().
+	lint *treeBuilder
+
+	// Whether this renders part of another response, made by sub(). The
+	// other response lints the whole page.
+	nested bool
 
-	return h.singleTag(tag, attrs)
+	// Automatic flush points.
+	flushAfterHead bool
+	flushEveryRows int
+	rowCount       int
 }
 
-func (h *HtmlResponse) singleTag(tag string, attrs []attr) *HtmlResponse {
-	h.WriteStr("<")
-	h.WriteStr(tag)
+// Configures an HtmlResponse at construction time.
+type Option func(*HtmlResponse)
 
-	for _, a := range attrs {
-		h.WriteStr(" ")
-		h.WriteStr(a.name)
-		h.WriteStr("=\"")
-		h.WriteStr(html.EscapeString(a.value))
-		h.WriteStr("\"")
+func New(w io.Writer, options ...Option) *HtmlResponse {
+	h := &HtmlResponse{
+		w:     bufio.NewWriter(w),
+		out:   w,
+		stack: list.New(),
 	}
 
-	h.WriteStr(">")
+	for _, option := range options {
+		option(h)
+	}
 
 	return h
 }
 
-func (h *HtmlResponse) closeTag(tag string) *HtmlResponse {
-	h.WriteStr("</")
-	h.WriteStr(tag)
-	h.WriteStr(">")
+func (h *HtmlResponse) WriteStr(s string) {
+	if h.tree != nil {
+		h.tree.addText(RawNode, s)
+		return
+	}
+	if h.err != nil {
+		return
+	}
 
-	back := h.stack.Back()
-	if checkTagPairing {
-		if back == nil {
-			panic("too many closing tags")
-		}
-		backTag := back.Value.(string)
-		if backTag != tag {
-			panic("mismatched tag (/" + tag + ", expected /" + backTag + ")")
-		}
+	if s == "" {
+		return
 	}
-	h.stack.Remove(back)
 
-	return h
+	_, err := h.w.WriteString(s)
+	if err != nil {
+		h.err = err
+	}
+	h.midLine = s[len(s)-1] != '\n'
 }
 
-func (h *HtmlResponse) autoCloseTag() *HtmlResponse {
-	back := h.stack.Back()
-	if back == nil {
-		panic("too many closing tags")
+// Flush buffered output to the underlying writer, and on to the client if
+// the writer is an http.ResponseWriter that implements http.Flusher. If that
+// fails, the error is available from Err() and further writes are skipped.
+func (h *HtmlResponse) Flush() *HtmlResponse {
+	if h.err != nil {
+		return h
 	}
-	backTag := back.Value.(string)
 
-	return h.closeTag(backTag)
-}
+	err := h.w.Flush()
+	if err != nil {
+		h.err = err
+		return h
+	}
 
-func (h *HtmlResponse) Doctype() *HtmlResponse {
-	h.WriteStr("<!DOCTYPE html>\n")
+	if flusher, ok := h.out.(http.Flusher); ok {
+		flusher.Flush()
+	}
 	return h
 }
 
-func (h *HtmlResponse) Html(attrs ...attr) *HtmlResponse {
-	return h.openTag("html", attrs)
+// Return the first write error (for example because the client went away),
+// followed by any tag errors recorded so far, or nil if everything went well.
+// Handlers can check this to stop expensive rendering early.
+func (h *HtmlResponse) Err() error {
+	return errors.Join(append([]error{h.err}, h.tagErrors...)...)
 }
 
-func (h *HtmlResponse) Html_() *HtmlResponse {
-	return h.closeTag("html")
-}
+// Finish the response: reports any elements still open, flushes the output,
+// and returns Err().
+func (h *HtmlResponse) Close() error {
+	if h.validation != ValidateOff {
+		h.checkUnclosed()
+	}
+	if h.lint != nil && !h.nested {
+		h.reportLintErrors()
+	}
+	if h.mode == OutputPretty && h.midLine {
+		h.WriteStr("\n")
+	}
+	h.Flush()
 
-func (h *HtmlResponse) Head(attrs ...attr) *HtmlResponse {
-	return h.openTag("head", attrs)
+	return h.Err()
 }
 
-func (h *HtmlResponse) Head_() *HtmlResponse {
-	return h.closeTag("head")
-}
+func (h *HtmlResponse) openTag(tag string, attrs []attr) *HtmlResponse {
+	attrs = h.addNonce(tag, attrs)
+
+	if h.tree != nil {
+		h.tree.addElement(tag, attrs, false, true)
+	} else {
+		h.writeStartTag(tag, attrs, false)
+		if h.lint != nil {
+			h.lint.addElement(tag, attrs, false, true)
+		}
+	}
+	h.stack.PushBack(newElement(tag, attrs))
 
-func (h *HtmlResponse) Body(attrs ...attr) *HtmlResponse {
-	return h.openTag("body", attrs)
+	return h
 }
 
-func (h *HtmlResponse) Body_() *HtmlResponse {
-	return h.closeTag("body")
-}
+func (h *HtmlResponse) singleTag(tag string, attrs []attr) *HtmlResponse {
+	attrs = h.addNonce(tag, attrs)
 
-func (h *HtmlResponse) Title(attrs ...attr) *HtmlResponse {
-	return h.openTag("title", attrs)
-}
+	if h.tree != nil {
+		h.tree.addElement(tag, attrs, false, false)
+		return h
+	}
 
-func (h *HtmlResponse) Title_() *HtmlResponse {
-	return h.closeTag("title")
-}
+	// XML has no void elements.
+	h.writeStartTag(tag, attrs, h.xml)
+	if h.lint != nil {
+		h.lint.addElement(tag, attrs, false, false)
+	}
 
-func (h *HtmlResponse) Script(attrs ...attr) *HtmlResponse {
-	return h.openTag("script", attrs)
+	return h
 }
 
-func (h *HtmlResponse) Script_() *HtmlResponse {
-	return h.closeTag("script")
+// Write an opening or void tag, laid out for the output mode.
+func (h *HtmlResponse) writeStartTag(tag string, attrs []attr, selfClosing bool) {
+	h.formatBeforeTag(tag)
+	h.writeTag(tag, attrs, selfClosing)
 }
 
-func (h *HtmlResponse) ScriptLink(url string) *HtmlResponse {
-	return h.Script(Src(url)).Script_()
-}
+// Write an opening tag, or a self-closing one ("<tag/>") if selfClosing.
+func (h *HtmlResponse) writeTag(tag string, attrs []attr, selfClosing bool) {
+	h.WriteStr("<")
+	h.WriteStr(tag)
 
-func (h *HtmlResponse) Link(attrs ...attr) *HtmlResponse {
-	return h.singleTag("link", attrs)
-}
+	for _, a := range attrs {
+		if a.name == "" {
+			// Left out by AttrIf().
+			continue
+		}
+		if eventHandlerAttrs[a.name] && !a.trusted {
+			// No escaping makes untrusted code safe to run.
+			h.tagError("untrusted " + a.name + " handler (wrap it in Trust())")
+			continue
+		}
 
-func (h *HtmlResponse) StyleLink(href string) *HtmlResponse {
-	return h.Link(Rel("stylesheet"), Href(href))
-}
+		h.WriteStr(" ")
+		h.WriteStr(a.name)
+		if a.boolean {
+			// XML has no attributes without values.
+			if h.xml {
+				h.WriteStr("=\"" + a.name + "\"")
+			}
+			continue
+		}
+		h.WriteStr("=\"")
+		h.WriteStr(escapeAttr(a))
+		h.WriteStr("\"")
+	}
 
-func (h *HtmlResponse) Div(attrs ...attr) *HtmlResponse {
-	return h.openTag("div", attrs)
+	if selfClosing {
+		h.WriteStr("/>")
+	} else {
+		h.WriteStr(">")
+	}
 }
 
-func (h *HtmlResponse) Div_() *HtmlResponse {
-	return h.closeTag("div")
-}
+func (h *HtmlResponse) closeTag(tag string) *HtmlResponse {
+	if h.layout != nil {
+		h.layout.beforeClose(h, tag)
+	}
 
-func (h *HtmlResponse) P(attrs ...attr) *HtmlResponse {
-	return h.openTag("p", attrs)
-}
+	if h.lint != nil {
+		h.lint.closeElement(tag)
+	}
 
-func (h *HtmlResponse) P_() *HtmlResponse {
-	return h.closeTag("p")
-}
+	if h.tree != nil {
+		h.tree.closeElement(tag)
+	} else if h.formatBeforeCloseTag(tag) {
+		h.WriteStr("</")
+		h.WriteStr(tag)
+		h.WriteStr(">")
+	}
 
-func (h *HtmlResponse) B(attrs ...attr) *HtmlResponse {
-	return h.openTag("b", attrs)
-}
+	h.popTag(tag)
+	h.flushAfterClose(tag)
 
-func (h *HtmlResponse) B_() *HtmlResponse {
-	return h.closeTag("b")
+	return h
 }
 
-func (h *HtmlResponse) A(attrs ...attr) *HtmlResponse {
-	return h.openTag("a", attrs)
-}
+// Return whether an element with this tag is currently open.
+func (h *HtmlResponse) isOpen(tag string) bool {
+	for e := h.stack.Back(); e != nil; e = e.Prev() {
+		if e.Value.(*element).tag == tag {
+			return true
+		}
+	}
 
-func (h *HtmlResponse) A_() *HtmlResponse {
-	return h.closeTag("a")
+	return false
 }
 
-func (h *HtmlResponse) Ul(attrs ...attr) *HtmlResponse {
-	return h.openTag("ul", attrs)
-}
+// Make a response with the same configuration that writes to w, for rendering
+// part of a page separately.
+func (h *HtmlResponse) sub(w io.Writer) *HtmlResponse {
+	s := *h
+	s.w = bufio.NewWriter(w)
+	s.out = w
+	s.stack = list.New()
+	s.err = nil
+	s.tagErrors = nil
+	s.layout = nil
+	s.tree = nil
+	s.nested = true
 
-func (h *HtmlResponse) Ul_() *HtmlResponse {
-	return h.closeTag("ul")
+	return &s
 }
 
-func (h *HtmlResponse) Li(attrs ...attr) *HtmlResponse {
-	return h.openTag("li", attrs)
-}
+func (h *HtmlResponse) autoCloseTag() *HtmlResponse {
+	back := h.stack.Back()
+	if back == nil {
+		h.tagError("too many closing tags")
+		return h
+	}
+	backTag := back.Value.(*element).tag
 
-func (h *HtmlResponse) Li_() *HtmlResponse {
-	return h.closeTag("li")
+	return h.closeTag(backTag)
 }
 
-func (h *HtmlResponse) Table(attrs ...attr) *HtmlResponse {
-	return h.openTag("table", attrs)
-}
+func (h *HtmlResponse) Doctype() *HtmlResponse {
+	if h.tree != nil {
+		h.tree.add(&Node{Type: DoctypeNode})
+		return h
+	}
 
-func (h *HtmlResponse) Table_() *HtmlResponse {
-	return h.closeTag("table")
+	h.WriteStr("<!DOCTYPE html>")
+	if h.mode != OutputMinified {
+		h.WriteStr("\n")
+	}
+	return h
 }
 
-func (h *HtmlResponse) Tr(attrs ...attr) *HtmlResponse {
-	return h.openTag("tr", attrs)
-}
+// Link to a script. Inside a layout, this is hoisted to the bottom of the page.
+// With WithAssets(), the URL may be the name of an asset.
+func (h *HtmlResponse) ScriptLink(url string) *HtmlResponse {
+	if h.layout != nil && !h.layout.hoistScript(h, url) {
+		return h
+	}
 
-func (h *HtmlResponse) Tr_() *HtmlResponse {
-	return h.closeTag("tr")
+	return h.scriptTag(url)
 }
 
-func (h *HtmlResponse) Td(attrs ...attr) *HtmlResponse {
-	return h.openTag("td", attrs)
-}
+// Link to a stylesheet. Inside a layout, this is hoisted to the <head>.
+// With WithAssets(), the URL may be the name of an asset.
+func (h *HtmlResponse) StyleLink(href string) *HtmlResponse {
+	if h.layout != nil && !h.layout.hoistStyle(h, href) {
+		return h
+	}
 
-func (h *HtmlResponse) Td_() *HtmlResponse {
-	return h.closeTag("td")
+	return h.styleTag(href)
 }
 
-func (h *HtmlResponse) Img(attrs ...attr) *HtmlResponse {
-	return h.singleTag("img", attrs)
-}
+// Write escaped text. The escaping depends on the enclosing element: inside
+// <script> it's escaped for a JavaScript string, inside <style> for a CSS
+// string, and elsewhere for HTML.
+func (h *HtmlResponse) Text(s string) *HtmlResponse {
+	if h.tree != nil {
+		h.tree.addText(TextNode, s)
+		return h
+	}
 
-func (h *HtmlResponse) Meta(attrs ...attr) *HtmlResponse {
-	return h.singleTag("meta", attrs)
-}
+	if h.lint != nil {
+		h.lint.addText(TextNode, s)
+	}
 
-func (h *HtmlResponse) Text(s string) *HtmlResponse {
-	return h.RawText(html.EscapeString(s))
+	h.WriteStr(h.escapeText(h.formatText(s)))
+	return h
 }
 
 func (h *HtmlResponse) RawText(s string) *HtmlResponse {
+	if h.lint != nil && h.tree == nil {
+		h.lint.addText(RawNode, s)
+	}
+
 	h.WriteStr(s)
 	return h
 }
@@ -0,0 +1,1166 @@
+package snake
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/lkesteloot/goutil/dbutil"
+	"github.com/lkesteloot/goutil/snake/i18n"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Render with the given options and return the output and the Close() error.
+func render(options []Option, f func(h *HtmlResponse)) (string, error) {
+	var buf bytes.Buffer
+	h := New(&buf, options...)
+	f(h)
+	err := h.Close()
+	return buf.String(), err
+}
+
+func TestValidationCollect(t *testing.T) {
+	_, err := render([]Option{WithValidation(ValidateCollect)}, func(h *HtmlResponse) {
+		h.Div(Id("main")).P(Class("intro wide")).Div_().Ul()
+	})
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+
+	msg := err.Error()
+	for _, expected := range []string{
+		"mismatched tag (/div, expected /p) at div#main > p.intro.wide",
+		"unclosed tag <ul> at ul",
+	} {
+		if !strings.Contains(msg, expected) {
+			t.Errorf("Error %q does not contain %q", msg, expected)
+		}
+	}
+}
+
+func TestValidationStrict(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(*TagError); !ok {
+			t.Error("expected a *TagError panic")
+		}
+	}()
+
+	render(nil, func(h *HtmlResponse) {
+		h.Div().Div_().Div_()
+	})
+}
+
+// Writer that fails after accepting a fixed number of bytes.
+type failingWriter struct {
+	remaining int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.remaining {
+		n := w.remaining
+		w.remaining = 0
+		return n, errors.New("connection reset")
+	}
+	w.remaining -= len(p)
+	return len(p), nil
+}
+
+func TestWriteError(t *testing.T) {
+	h := New(&failingWriter{10})
+	h.Div().Text("hello").Div_().Flush()
+	if h.Err() == nil {
+		t.Fatal("expected a write error after Flush")
+	}
+
+	// Later writes are dropped and the error stays the same.
+	first := h.Err()
+	h.P().Text(strings.Repeat("x", 10000)).P_()
+	if h.Close().Error() != first.Error() {
+		t.Errorf("error changed from %q to %q", first, h.Err())
+	}
+}
+
+// A component with a child, as in the Component doc comment.
+type testCard struct {
+	Title string
+	Body  Component
+}
+
+func (c testCard) Render(h *HtmlResponse) {
+	h.Div(Class("card")).
+		H2().Content(c.Title).
+		Div(Class("card-body")).Render(c.Body).Div_().
+		Div_()
+}
+
+func TestComponents(t *testing.T) {
+	out, err := render(nil, func(h *HtmlResponse) {
+		h.Render(
+			testCard{
+				Title: "Fruit",
+				Body: ComponentFunc(func(h *HtmlResponse) {
+					h.Ul().Render(Each([]string{"Apple", "Pear"}, func(s string) Component {
+						return ComponentFunc(func(h *HtmlResponse) {
+							h.Li().Content(s)
+						})
+					})).Ul_()
+				}),
+			},
+			nil,
+			Group(TextComponent("a<b"), TextComponent("!")),
+		)
+		h.RenderIf(false, TextComponent("hidden")).
+			RenderIfElse(false, TextComponent("then"), TextComponent("otherwise"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<div class="card"><h2>Fruit</h2><div class="card-body">` +
+		`<ul><li>Apple</li><li>Pear</li></ul></div></div>a&lt;b!otherwise`
+	if out != expected {
+		t.Errorf("got\n%s\nexpected\n%s", out, expected)
+	}
+}
+
+func TestLayoutHoisting(t *testing.T) {
+	base := NewLayout(ComponentFunc(func(h *HtmlResponse) {
+		h.Html().
+			Head().Title().Block(BlockTitle).Title_().StyleLink("/base.css").Head_().
+			Body().Block(BlockContent).Block(BlockScripts).Body_().
+			Html_()
+	}), Blocks{BlockTitle: TextComponent("Site")})
+	admin := base.Extend(Blocks{BlockTitle: TextComponent("Admin")})
+
+	out, err := render(nil, func(h *HtmlResponse) {
+		h.Layout(admin, Blocks{
+			BlockContent: ComponentFunc(func(h *HtmlResponse) {
+				h.StyleLink("/base.css").StyleLink("/page.css").ScriptLink("/page.js")
+				h.P().Content("Hi").ScriptLink("/page.js")
+			}),
+			BlockScripts: ComponentFunc(func(h *HtmlResponse) {
+				h.Script().RawContent("init();")
+			}),
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<html><head><title>Admin</title><link rel="stylesheet" href="/base.css">` +
+		`<link rel="stylesheet" href="/page.css"></head>` +
+		`<body><p>Hi</p><script src="/page.js"></script><script>init();</script></body></html>`
+	if out != expected {
+		t.Errorf("got\n%s\nexpected\n%s", out, expected)
+	}
+}
+
+func TestContextEscaping(t *testing.T) {
+	out, _ := render(nil, func(h *HtmlResponse) {
+		h.A(Href("javascript:alert(1)")).A_()
+		h.A(Href("/page?a=1&b=2")).A_()
+		h.A(Trust(Href("javascript:void(0)"))).A_()
+		h.Div(Trust(OnClick(`toggle('menu')`)), Attr("one", "javascript:x")).Div_()
+		h.Script().RawText(`var s = "`).Text(`</script>"`).RawText(`";`).Script_()
+		h.Style().Text(`x"}`).Style_()
+	})
+
+	expected := `<a href="about:invalid#zSnakez"></a>` +
+		`<a href="/page?a=1&amp;b=2"></a>` +
+		`<a href="javascript:void(0)"></a>` +
+		`<div onclick="toggle(&#39;menu&#39;)" one="javascript:x"></div>` +
+		`<script>var s = "\u003C\u002Fscript\u003E\u0022";</script>` +
+		`<style>x\22 \7D </style>`
+	if out != expected {
+		t.Errorf("got\n%s\nexpected\n%s", out, expected)
+	}
+}
+
+func TestUntrustedHandler(t *testing.T) {
+	out, err := render([]Option{WithValidation(ValidateCollect)}, func(h *HtmlResponse) {
+		h.Div(OnClick(`"><x`), Id("a")).Div_()
+	})
+
+	if out != `<div id="a"></div>` {
+		t.Errorf("got %s", out)
+	}
+	if err == nil || !strings.Contains(err.Error(), "untrusted onclick handler") {
+		t.Errorf("expected an error for the handler, got %v", err)
+	}
+}
+
+func TestSanitized(t *testing.T) {
+	untrusted := `<p onclick="x()">Hi <b>there<script>alert(1)</script>` +
+		`<a href="javascript:bad()">a</a> <a href="https://example.com/" rel="me">b</a>` +
+		`<img src=/cat.png alt='A &amp; B'><div>c</p></i>`
+
+	out, _ := render(nil, func(h *HtmlResponse) {
+		h.Sanitized(untrusted, LinksAndImagesPolicy())
+	})
+
+	expected := `<p>Hi <b>there<a rel="nofollow noopener">a</a> ` +
+		`<a href="https://example.com/" rel="nofollow noopener">b</a>` +
+		`<img src="/cat.png" alt="A &amp; B">c</b></p>`
+	if out != expected {
+		t.Errorf("got\n%s\nexpected\n%s", out, expected)
+	}
+}
+
+func TestSanitizedUrlSchemes(t *testing.T) {
+	policy := LinksAndImagesPolicy().AllowUrlSchemes("ftp", "data")
+	out, _ := render(nil, func(h *HtmlResponse) {
+		h.Sanitized(`<a href="ftp://example.com/f">f</a><img src="data:image/png;base64,AAAA">`+
+			`<a href="javascript:bad()">j</a>`, policy)
+	})
+
+	expected := `<a href="ftp://example.com/f" rel="nofollow noopener">f</a>` +
+		`<img src="data:image/png;base64,AAAA"><a rel="nofollow noopener">j</a>`
+	if out != expected {
+		t.Errorf("got\n%s\nexpected\n%s", out, expected)
+	}
+}
+
+func TestRawTextCaseChangingRunes(t *testing.T) {
+	// These runes change length when lowercased.
+	out, _ := render(nil, func(h *HtmlResponse) {
+		h.Sanitized("<script>"+strings.Repeat("\u023A", 10)+"</script", LinksAndImagesPolicy())
+		h.Sanitized("<p>x</p>", LinksAndImagesPolicy())
+	})
+	if out != "<p>x</p>" {
+		t.Errorf("got %s", out)
+	}
+
+	root, err := Parse(strings.NewReader("<textarea>\u212A\u212A</TEXTAREA><title>\u0130</title>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text := root.FindByTag("textarea")[0].TextContent(); text != "\u212A\u212A" {
+		t.Errorf("got textarea %q", text)
+	}
+	if text := root.FindByTag("title")[0].TextContent(); text != "\u0130" {
+		t.Errorf("got title %q", text)
+	}
+}
+
+func TestOutputModes(t *testing.T) {
+	page := func(h *HtmlResponse) {
+		h.Html().Body().
+			Div(Class("a")).P().Text("Some  ").B().Text("bold").B_().Text("\n text").P_().
+			Ul().Li().Text("one").Li_().Li().Text("two").Li_().Ul_().
+			Pre().Text("  keep\n  this").Pre_().
+			Div_().
+			Body_().Html_()
+	}
+
+	pretty, _ := render([]Option{WithOutputMode(OutputPretty)}, page)
+	expected := `<html>
+  <body>
+    <div class="a">
+      <p>Some  <b>bold</b>
+ text</p>
+      <ul>
+        <li>one</li>
+        <li>two</li>
+      </ul>
+      <pre>  keep
+  this</pre>
+    </div>
+  </body>
+</html>
+`
+	if pretty != expected {
+		t.Errorf("got\n%s\nexpected\n%s", pretty, expected)
+	}
+
+	minified, _ := render([]Option{WithOutputMode(OutputMinified)}, page)
+	expected = `<html><body><div class="a"><p>Some <b>bold</b> text</p>` +
+		`<ul><li>one<li>two</ul><pre>  keep
+  this</pre></div>`
+	if minified != expected {
+		t.Errorf("got\n%s\nexpected\n%s", minified, expected)
+	}
+
+	// Only HTML whitespace collapses, not non-breaking spaces.
+	nbsp := func(h *HtmlResponse) {
+		h.P().Text("10\u00a0km  \u00a0 away").P_()
+	}
+	minified, _ = render([]Option{WithOutputMode(OutputMinified)}, nbsp)
+	if minified != "<p>10\u00a0km \u00a0 away</p>" {
+		t.Errorf("got %q", minified)
+	}
+	if text, _ := RenderText(ComponentFunc(nbsp)); text != "10\u00a0km \u00a0 away\n" {
+		t.Errorf("got %q", text)
+	}
+}
+
+// Writer that counts calls to Flush(), like an http.ResponseWriter.
+type flushCounter struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (f *flushCounter) Flush() {
+	f.flushes++
+}
+
+func TestFlushPoints(t *testing.T) {
+	var w flushCounter
+	h := New(&w, WithFlushAfterHead(), WithFlushEveryRows(2))
+	h.Html().Head().Title().Content("Report").Head_()
+	if w.flushes != 1 || !strings.HasSuffix(w.String(), "</head>") {
+		t.Errorf("head not flushed: %d flushes, output %q", w.flushes, w.String())
+	}
+
+	h.Body().Table()
+	for i := 0; i < 5; i++ {
+		h.Tr().Td().Content("x").Tr_()
+	}
+	if w.flushes != 3 {
+		t.Errorf("expected 3 flushes, got %d", w.flushes)
+	}
+}
+
+func TestLayoutFlushPoints(t *testing.T) {
+	var w flushCounter
+	base := NewLayout(ComponentFunc(func(h *HtmlResponse) {
+		h.Html().Head().StyleLink("/base.css").Head_().
+			Body().Block(BlockContent).Block(BlockScripts).Body_().Html_()
+	}), nil)
+
+	h := New(&w, WithFlushAfterHead(), WithFlushEveryRows(2))
+	h.Layout(base, Blocks{
+		BlockContent: ComponentFunc(func(h *HtmlResponse) {
+			if w.flushes != 1 || !strings.HasSuffix(w.String(), "</head>") {
+				t.Errorf("head not flushed: %d flushes, output %q", w.flushes, w.String())
+			}
+
+			h.ScriptLink("/page.js").StyleLink("/late.css").Table()
+			for i := 0; i < 3; i++ {
+				h.Tr().Td().Content(strconv.Itoa(i)).Tr_()
+			}
+			if w.flushes != 2 || !strings.HasSuffix(w.String(), "<td>1</td></tr>") {
+				t.Errorf("rows not flushed: %d flushes, output %q", w.flushes, w.String())
+			}
+			h.Table_()
+		}),
+	})
+	if err := h.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<html><head><link rel="stylesheet" href="/base.css"></head><body>` +
+		`<link rel="stylesheet" href="/late.css"><table><tr><td>0</td></tr><tr><td>1</td></tr>` +
+		`<tr><td>2</td></tr></table><script src="/page.js"></script></body></html>`
+	if w.String() != expected {
+		t.Errorf("got\n%s\nexpected\n%s", w.String(), expected)
+	}
+}
+
+func TestDataTableSort(t *testing.T) {
+	type item struct {
+		Name  string       `snake:"Item"`
+		Price dbutil.Money `snake:"Price"`
+		Note  string       `snake:"-"`
+	}
+	items := []item{
+		{"Box 10", dbutil.NewMoney(150), ""},
+		{"Box 9", dbutil.NullMoney(), ""},
+		{"Box 2", dbutil.NewMoney(99), ""},
+	}
+
+	r := httptest.NewRequest("GET", "/items?page=2&sort=name&dir=desc", nil)
+	out, _ := render(nil, NewDataTable(items, nil).SortFrom(r).Render)
+
+	expected := `<table class="data-table"><thead><tr>` +
+		`<th class="sorted-desc"><a href="?dir=asc&amp;page=2&amp;sort=name">Item</a></th>` +
+		`<th><a href="?dir=asc&amp;page=2&amp;sort=price">Price</a></th></tr></thead><tbody>` +
+		`<tr><td>Box 10</td><td>$1.50</td></tr>` +
+		`<tr><td>Box 9</td><td></td></tr>` +
+		`<tr><td>Box 2</td><td>$0.99</td></tr></tbody></table>`
+	if out != expected {
+		t.Errorf("got\n%s\nexpected\n%s", out, expected)
+	}
+}
+
+func TestFormBuilder(t *testing.T) {
+	out, err := render(nil, func(h *HtmlResponse) {
+		f := NewFormBuilder(h, "order", FieldErrors{"price": "Not a valid amount"})
+		f.TextField("note", "Note", "a<b").
+			MoneyField("price", "Price", dbutil.NewMoney(150)).
+			BooleanField("gift", "Gift", dbutil.NewBoolean(true)).
+			IdField("category", "Category", 0, []SelectOption{{1, "Books"}, {2, "Music"}}, false).
+			IdField("shelf", "Shelf", 2, []SelectOption{{1, "Top"}, {2, "Bottom"}}, true).
+			IdField("size", "Size", 1, []SelectOption{{1, "S"}}, false)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<div class="field"><label for="order-note">Note</label>` +
+		`<input type="text" id="order-note" name="note" value="a&lt;b"></div>` +
+		`<div class="field has-error"><label for="order-price">Price</label>` +
+		`<input type="text" id="order-price" name="price" value="$1.50">` +
+		`<span class="error">Not a valid amount</span></div>` +
+		`<div class="field"><label for="order-gift">Gift</label>` +
+		`<input type="checkbox" id="order-gift" name="gift" value="true" checked></div>` +
+		`<div class="field"><label for="order-category">Category</label>` +
+		`<select id="order-category" name="category"><option value="" selected></option>` +
+		`<option value="1">Books</option><option value="2">Music</option></select></div>` +
+		`<div class="field"><label for="order-shelf">Shelf</label>` +
+		`<select id="order-shelf" name="shelf"><option value=""></option>` +
+		`<option value="1">Top</option><option value="2" selected>Bottom</option></select></div>` +
+		`<div class="field"><label for="order-size">Size</label>` +
+		`<select id="order-size" name="size"><option value="1" selected>S</option></select></div>`
+	if out != expected {
+		t.Errorf("got\n%s\nexpected\n%s", out, expected)
+	}
+}
+
+func TestFormValues(t *testing.T) {
+	form := url.Values{
+		"price":    {" $1.5 "},
+		"blank":    {"  "},
+		"bad":      {"$"},
+		"discount": {"12.5 %"},
+		"tabbed":   {"5\t%"},
+		"sign":     {"-"},
+		"category": {" 7 "},
+		"other":    {"seven"},
+	}
+	r := httptest.NewRequest("POST", "/items", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	v := NewFormValues(r)
+
+	if m := v.Money("price"); m.IsNull || m.Pennies != 150 {
+		t.Errorf("got price %+v", m)
+	}
+	if m := v.Money("blank"); !m.IsNull {
+		t.Errorf("expected null money for a blank field, got %+v", m)
+	}
+	if m := v.Money("missing"); !m.IsNull {
+		t.Errorf("expected null money for a missing field, got %+v", m)
+	}
+	v.Money("bad")
+	if p := v.Percent("discount", true); p.IsNull || p.Value != 12.5 {
+		t.Errorf("got discount %+v", p)
+	}
+	if p := v.Percent("blank", true); !p.IsNull {
+		t.Errorf("expected a null percentage, got %+v", p)
+	}
+	v.Percent("tabbed", false)
+	v.Percent("sign", false)
+	if id := v.IdField("category"); id != 7 {
+		t.Errorf("got category %d", id)
+	}
+	if id := v.IdField("blank"); id != 0 {
+		t.Errorf("got blank category %d", id)
+	}
+	v.IdField("other")
+
+	expected := FieldErrors{
+		"bad":    "Not a valid amount",
+		"tabbed": "Not a valid percentage",
+		"sign":   "Not a valid percentage",
+		"other":  "Not a valid choice",
+	}
+	if !reflect.DeepEqual(v.Errors, expected) {
+		t.Errorf("got errors %v, expected %v", v.Errors, expected)
+	}
+}
+
+func TestXmlAndSvg(t *testing.T) {
+	out, _ := render(nil, func(h *HtmlResponse) {
+		h.Svg(ViewBox("0 0 10 10")).
+			SvgPath(D("M0 0L10 10"), Stroke("red")).
+			SvgText(X("1"), Y("2")).Text("a<b").SvgText_().
+			Svg_()
+	})
+	expected := `<svg viewBox="0 0 10 10"><path d="M0 0L10 10" stroke="red"/>` +
+		`<text x="1" y="2">a&lt;b</text></svg>`
+	if out != expected {
+		t.Errorf("got\n%s\nexpected\n%s", out, expected)
+	}
+
+	out, _ = render([]Option{WithXml(), WithOutputMode(OutputPretty)}, func(h *HtmlResponse) {
+		h.XmlDeclaration().
+			Element("feed", Xmlns(AtomNamespace), XmlnsPrefix("media", "http://search.yahoo.com/mrss/")).
+			Element("title").Text("News").Element_("title").
+			Element("link").Text("https://example.com/").Element_("link").
+			EmptyElement("media:thumbnail", NsAttr("media", "url", "/a.png")).
+			Element("content").CData("x]]>y").Element_("content").
+			Br().
+			Element_("feed")
+	})
+	expected = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/">
+  <title>News</title>
+  <link>https://example.com/</link>
+  <media:thumbnail media:url="/a.png"/>
+  <content><![CDATA[x]]]]><![CDATA[>y]]></content>
+  <br/>
+</feed>
+`
+	if out != expected {
+		t.Errorf("got\n%s\nexpected\n%s", out, expected)
+	}
+}
+
+func TestTree(t *testing.T) {
+	h := NewTree(WithOutputMode(OutputPretty))
+	h.Doctype().Html().Head().Title().Content("T").Head_().
+		Body().Div(Id("main"), Class("a")).P().Text("x < y").P_().Img(Src("/i.png")).Div_().Body_().
+		Html_()
+	if err := h.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	doc := h.Tree()
+	doc.FindByTag("body")[0].AddClass("dark")
+	main := doc.FindById("main")
+	main.RemoveClass("a").SetAttr("data-x", "1")
+	doc.FindByTag("head")[0].AppendComponent(ComponentFunc(func(h *HtmlResponse) {
+		h.Script().Text("a\"b").Script_()
+	}))
+	main.PrependChild(NewElement("hr"))
+	if text := main.TextContent(); text != "x < y" {
+		t.Errorf("got text %q", text)
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<!DOCTYPE html>
+<html><head><title>T</title><script>a\u0022b</script></head>` +
+		`<body class="dark"><div id="main" data-x="1"><hr><p>x &lt; y</p><img src="/i.png"></div></body></html>`
+	if buf.String() != expected {
+		t.Errorf("got\n%s\nexpected\n%s", buf.String(), expected)
+	}
+}
+
+func TestParse(t *testing.T) {
+	doc, err := Parse(strings.NewReader(`<!DOCTYPE html><ul><li>One<li>Two &amp; <b>three</b></ul>` +
+		`<p>A<div>B</div><!-- c --><svg viewbox="0 0 1 1"><circle r="1"/></svg><script>a < b</script>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(doc.FindByTag("li")); n != 2 {
+		t.Errorf("got %d li elements", n)
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := "<!DOCTYPE html>\n<ul><li>One</li><li>Two &amp; <b>three</b></li></ul><p>A</p><div>B</div>" +
+		`<!-- c --><svg viewBox="0 0 1 1"><circle r="1"/></svg><script>a < b</script>`
+	if buf.String() != expected {
+		t.Errorf("got\n%s\nexpected\n%s", buf.String(), expected)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	doc, err := Parse(strings.NewReader(`<div id="a" class="x y"><p>1</p><p lang="en-US">2</p>` +
+		`<span>3</span><p>4</p></div><ul><li>a<li>b<li>c<li>d</ul>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		selector string
+		expected string
+	}{
+		{"p", "124"},
+		{"div.x.y > p:first-child", "1"},
+		{"#a p + span", "3"},
+		{"p ~ p", "24"},
+		{"[lang|=en], span", "23"},
+		{"li:nth-child(odd)", "ac"},
+		{"li:nth-child(2n)", "bd"},
+		{"li:nth-last-child(1)", "d"},
+		{"div *:not(p)", "3"},
+		{"p:last-child", "4"},
+		{"[class~=y]:only-child", ""},
+	}
+
+	for _, test := range tests {
+		found, err := doc.Query(test.selector)
+		if err != nil {
+			t.Errorf("%q: %s", test.selector, err)
+			continue
+		}
+
+		text := ""
+		for _, n := range found {
+			text += n.TextContent()
+		}
+		if text != test.expected {
+			t.Errorf("%q: got %q, expected %q", test.selector, text, test.expected)
+		}
+	}
+
+	for _, selector := range []string{"", "p >", "[x", "p:bogus", "a,,b", "p!"} {
+		if _, err := CompileSelector(selector); err == nil {
+			t.Errorf("%q: expected error", selector)
+		}
+	}
+
+	// :not() counts as its argument.
+	div := doc.FindById("a")
+	for selector, expected := range map[string]int{"#a": 10000, ":not(#b)": 10000, ":not(.z)": 100, ".x:not(p)": 101} {
+		s, err := CompileSelector(selector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if specificity, _ := s.specificity(div); specificity != expected {
+			t.Errorf("%q: got specificity %d, expected %d", selector, specificity, expected)
+		}
+	}
+}
+
+func TestAttrHelpers(t *testing.T) {
+	out, err := render(nil, func(h *HtmlResponse) {
+		h.Input(Type("checkbox"), Checked(), AttrIf(false, Disabled()), Data("id", "7"), Aria("label", "Pick")).
+			Div(Classes("a b", ClassIf(false, "c"), ClassIf(true, "d"), "b a"),
+				Styles(Prop("color", "#fff"), Prop("width", ""), Prop("margin", 0), Prop("color", "red;x:y"),
+					Prop("bad name", "1"), Prop("background", "url(evil)"))).Div_().
+			Span(Classes(ClassIf(false, "x"), " "), Styles(Prop("--mainColor", "red"), Prop("COLOR", "blue"))).Span_()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<input type="checkbox" checked data-id="7" aria-label="Pick">` +
+		`<div class="a b d" style="color: red\3B x:y; margin: 0; background: zSnakez"></div>` +
+		`<span style="--mainColor: red; color: blue"></span>`
+	if out != expected {
+		t.Errorf("got\n%s\nexpected\n%s", out, expected)
+	}
+
+	out, err = render([]Option{WithXml()}, func(h *HtmlResponse) {
+		h.Element("option", Selected()).Element_("option")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<option selected="selected"></option>`; out != expected {
+		t.Errorf("got %s, expected %s", out, expected)
+	}
+}
+
+func TestAssets(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "js"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "js", "app.js"), []byte("alert(1)"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	assets, err := LoadAssets(dir, "https://cdn.example.com/assets")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := render([]Option{WithAssets(assets)}, func(h *HtmlResponse) {
+		h.ScriptLink("js/app.js").ScriptLink("/other.js")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha512.Sum384([]byte("alert(1)"))
+	hashed := "js/app." + hex.EncodeToString(sum[:])[:12] + ".js"
+	expected := `<script src="https://cdn.example.com/assets/` + hashed + `" integrity="sha384-` +
+		base64.StdEncoding.EncodeToString(sum[:]) + `" crossorigin="anonymous"></script>` +
+		`<script src="/other.js"></script>`
+	if out != expected {
+		t.Errorf("got\n%s\nexpected\n%s", out, expected)
+	}
+
+	if p, ok := assets.FilePath(hashed); !ok || p != filepath.Join(dir, "js", "app.js") {
+		t.Errorf("got file path %q", p)
+	}
+	if _, ok := assets.FilePath("js/app.js"); ok {
+		t.Error("unhashed name should not be served")
+	}
+}
+
+func TestNonce(t *testing.T) {
+	out, err := render([]Option{WithNonce("abc")}, func(h *HtmlResponse) {
+		h.Script(Nonce("old")).Text("go()").Script_().
+			Style().Text("p{}").Style_().
+			StyleLink("https://cdn.example.com/a.css").
+			Link(Rel("icon"), Href("/favicon.ico")).
+			Div().Div_()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<script nonce="abc">go()</script><style nonce="abc">p\7B \7D </style>` +
+		`<link rel="stylesheet" href="https://cdn.example.com/a.css" nonce="abc">` +
+		`<link rel="icon" href="/favicon.ico"><div></div>`
+	if out != expected {
+		t.Errorf("got\n%s\nexpected\n%s", out, expected)
+	}
+}
+
+func TestTranslation(t *testing.T) {
+	fr, _ := i18n.LookupLocale("fr")
+	catalog := i18n.NewCatalog(fr).
+		Add("greeting", "Bonjour <{0}>").
+		AddPlural("items", map[i18n.PluralCategory]string{i18n.One: "{0} article", i18n.Other: "{0} articles"})
+
+	out, err := render([]Option{WithCatalog(catalog)}, func(h *HtmlResponse) {
+		h.P().T("greeting", "Ana").P_().
+			P().T("items", 0).T("items", 1500).P_()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "<p>Bonjour &lt;Ana&gt;</p><p>0 article1\u202f500 articles</p>"
+	if out != expected {
+		t.Errorf("got\n%s\nexpected\n%s", out, expected)
+	}
+}
+
+func TestLint(t *testing.T) {
+	_, err := render([]Option{WithLint()}, func(h *HtmlResponse) {
+		h.Html().Body().
+			H1().Content("Title").H3().Content("Skipped").
+			Img(Src("a.png")).Img(Src("b.png"), Alt("")).
+			Div(Id("x")).Div_().P(Id("x")).P_().
+			A(Href("/")).A_().A(Href("/")).Img(Src("c.png"), Alt("Home")).A_().
+			Input(Type("text"), Id("name")).Label(For("name")).Content("Name").
+			Input(Type("text")).Input(Type("submit")).Input(Type("IMAGE"), Src("go.png")).
+			Table().Tr().Td().Content("1").Tr_().Table_().
+			Table().Tr().Td().Table().Tr().Th().Content("h").Tr_().Table_().Td_().Tr_().Table_().
+			Body_().Html_()
+	})
+
+	if err == nil {
+		t.Fatal("expected lint errors")
+	}
+	expected := []string{
+		"<html> has no lang attribute at html",
+		"heading skips from <h1> to <h3> at html > body > h3",
+		"<img> has no alt attribute at html > body > img",
+		`duplicate id "x" at html > body > p#x`,
+		"link has no text at html > body > a",
+		"form field has no label at html > body > input",
+		"image button has no alt attribute at html > body > input",
+		"table has no header cells at html > body > table",
+		"table has no header cells at html > body > table",
+	}
+	if err.Error() != strings.Join(expected, "\n") {
+		t.Errorf("got\n%s\nexpected\n%s", err, strings.Join(expected, "\n"))
+	}
+
+	// Layouts render through a second response, but errors are reported once.
+	base := NewLayout(ComponentFunc(func(h *HtmlResponse) {
+		h.Html().Body().Block(BlockContent).Body_().Html_()
+	}), nil)
+	_, err = render([]Option{WithLint()}, func(h *HtmlResponse) {
+		h.Layout(base, Blocks{BlockContent: ComponentFunc(func(h *HtmlResponse) {
+			h.Img(Src("a.png"))
+		})})
+	})
+	expected = []string{
+		"<html> has no lang attribute at html",
+		"<img> has no alt attribute at html > body > img",
+	}
+	if err == nil || err.Error() != strings.Join(expected, "\n") {
+		t.Errorf("got\n%v\nexpected\n%s", err, strings.Join(expected, "\n"))
+	}
+
+	// The same checks work on a tree.
+	doc, _ := Parse(strings.NewReader(`<html lang="en"><img src="a.png"></html>`))
+	if errs := Lint(doc); len(errs) != 1 {
+		t.Errorf("got %d lint errors", len(errs))
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	page := ComponentFunc(func(h *HtmlResponse) {
+		h.Html().Head().Title().Content("Receipt").Head_().Body().
+			H1().Content("Your order").
+			P().Text("Thanks for   shopping with ").A(Href("https://example.com")).Content("Example").
+			Text(".").Br().Text("Questions? ").A(Href("mailto:help@example.com")).Content("help@example.com").P_().
+			Ul().Li().Content("Fast shipping").Li().Text("Returns").Ol().Li().Content("Within 30 days").Ol_().Li_().Ul_().
+			Table().Tr().Th().Content("Item").Th().Content("Price").Tr_().
+			Tr().Td().P().Content("Widget").Td_().Td().Content("$1.50").Tr_().
+			Tr().Td().Text("Gadget").Br().Text("deluxe").Td_().Td().Content("$10.00").Tr_().Table_().
+			BlockQuote().P().Content("Great!").BlockQuote_().
+			Pre().Text("a  b\n c").Pre_().
+			Body_().Html_()
+	})
+
+	text, err := RenderText(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `Your order
+==========
+
+Thanks for shopping with Example [https://example.com].
+Questions? help@example.com
+
+* Fast shipping
+* Returns
+  1. Within 30 days
+
+Item           Price
+-------------  ------
+Widget         $1.50
+Gadget deluxe  $10.00
+
+> Great!
+
+a  b
+ c
+`
+	if text != expected {
+		t.Errorf("got\n%s\nexpected\n%s", text, expected)
+	}
+
+	// Rows of a table inside a cell aren't rows of the outer table.
+	root, err := Parse(strings.NewReader(
+		`<table><tbody><tr><td>a</td><td><table><tr><td>b</td><td>c</td></tr></table></td></tr></tbody></table>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text := root.PlainText(); text != "a  b c\n" {
+		t.Errorf("got %q", text)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteMultipartAlternative(&buf, page); err != nil {
+		t.Fatal(err)
+	}
+	message := buf.String()
+	for _, s := range []string{"Content-Type: multipart/alternative; boundary=",
+		"Content-Type: text/plain; charset=utf-8", "Content-Type: text/html; charset=utf-8",
+		"Thanks for shopping", "<h1>Your order</h1>"} {
+
+		if !strings.Contains(message, s) {
+			t.Errorf("message doesn't contain %q", s)
+		}
+	}
+	if strings.Index(message, "text/plain") > strings.Index(message, "text/html") {
+		t.Error("HTML part should be last")
+	}
+}
+
+func TestEmailInlining(t *testing.T) {
+	s, err := ParseStylesheet(`
+		/* Base styles. */
+		p { color: #333; margin: 0 0 10px }
+		.note { color: gray; font-family: "Helvetica Neue", sans-serif }
+		#intro.note { color: black }
+		td p { margin: 0 !important }
+		a:hover { color: red }
+		@media (max-width: 600px) { p { font-size: 18px } }
+		div { display: flex }
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	warnings, err := RenderEmail(&buf, ComponentFunc(func(h *HtmlResponse) {
+		h.Html().Head().Head_().Body().
+			P(Id("intro"), Class("note")).Content("Hi").
+			P(Class("note"), Style("color: blue")).Content("There").
+			Table().Tr().Td().P(Style("margin: 5px")).Content("Cell").Td_().Tr_().Table_().
+			Script().Script_().
+			Body_().Html_()
+	}), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<html><head><style>a:hover { color: red }` + "\n" +
+		`@media (max-width: 600px) { p { font-size: 18px } }</style></head><body>` +
+		`<p id="intro" class="note" style="margin: 0 0 10px; font-family: &#34;Helvetica Neue&#34;, sans-serif; color: black">Hi</p>` +
+		`<p class="note" style="margin: 0 0 10px; font-family: &#34;Helvetica Neue&#34;, sans-serif; color: blue">There</p>` +
+		`<table><tr><td><p style="color: #333; margin: 0">Cell</p></td></tr></table>` +
+		`<script></script></body></html>`
+	if buf.String() != expected {
+		t.Errorf("got\n%s\nexpected\n%s", buf.String(), expected)
+	}
+
+	var messages []string
+	for _, w := range warnings {
+		messages = append(messages, w.String())
+	}
+	expectedWarnings := []string{
+		`"a:hover" can't be inlined and is ignored by some email clients`,
+		"@media (max-width: 600px) can't be inlined and is ignored by some email clients",
+		`"display: flex" is ignored by many email clients`,
+		"scripts are removed by email clients at html > body > script",
+	}
+	if strings.Join(messages, "\n") != strings.Join(expectedWarnings, "\n") {
+		t.Errorf("got warnings\n%s\nexpected\n%s", strings.Join(messages, "\n"), strings.Join(expectedWarnings, "\n"))
+	}
+
+	// A winning shorthand comes after the longhands it overrides.
+	s, err = ParseStylesheet(`p { margin: 0 } .b { margin-top: 5px } #c { margin: 1px }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	warnings, err = RenderEmail(&buf, ComponentFunc(func(h *HtmlResponse) {
+		h.P(Class("b"), Id("c"), Style("position: absolute")).P_()
+	}), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `<p class="b" id="c" style="margin-top: 5px; margin: 1px; position: absolute"></p>`
+	if buf.String() != expected {
+		t.Errorf("got\n%s\nexpected\n%s", buf.String(), expected)
+	}
+	if len(warnings) != 1 || warnings[0].String() != `the "position" property is ignored by many email clients at p#c.b` {
+		t.Errorf("got warnings %v", warnings)
+	}
+}
+
+func TestFragmentCache(t *testing.T) {
+	store := NewLruStore(2)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	calls := 0
+	menu := ComponentFunc(func(h *HtmlResponse) {
+		calls++
+		h.Ul().Li().Content("Books").Script().Script_().Ul_()
+	})
+	page := func(nonce string) string {
+		s, err := render([]Option{WithNonce(nonce)}, func(h *HtmlResponse) {
+			h.Nav().Cache(store, "menu", time.Minute, menu).Nav_()
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	expected := func(nonce string) string {
+		return `<nav><ul><li>Books</li><script nonce="` + nonce + `"></script></ul></nav>`
+	}
+	if s := page("a"); s != expected("a") || calls != 1 {
+		t.Errorf("first render: got %s after %d calls", s, calls)
+	}
+	if s := page("b"); s != expected("b") || calls != 1 {
+		t.Errorf("cached render: got %s after %d calls", s, calls)
+	}
+
+	// Without a nonce, the placeholder is taken out.
+	if s := page(""); s != `<nav><ul><li>Books</li><script></script></ul></nav>` || calls != 1 {
+		t.Errorf("cached render without a nonce: got %s after %d calls", s, calls)
+	}
+
+	store.Delete("menu")
+	page("")
+	if calls != 2 {
+		t.Errorf("expected a render after Delete(), got %d calls", calls)
+	}
+	if s := page("c"); s != expected("c") || calls != 2 {
+		t.Errorf("cached render with a nonce: got %s after %d calls", s, calls)
+	}
+
+	now = now.Add(time.Minute)
+	page("d")
+	if calls != 3 {
+		t.Errorf("expected a render after expiry, got %d calls", calls)
+	}
+
+	// The least recently used fragment is dropped.
+	store.Set("a", []byte("A"), 0)
+	store.Get("menu")
+	store.Set("b", []byte("B"), 0)
+	if _, ok := store.Get("a"); ok {
+		t.Error("expected a to be dropped")
+	}
+	if _, ok := store.Get("menu"); !ok || store.Len() != 2 {
+		t.Error("expected menu to be kept")
+	}
+
+	// Inside <script>, text is escaped for the script and isn't cached.
+	s, _ := render(nil, func(h *HtmlResponse) {
+		h.Script().Cache(store, "js", 0, ComponentFunc(func(h *HtmlResponse) {
+			h.Text(`"`)
+		})).Script_()
+	})
+	if s != `<script>\u0022</script>` {
+		t.Errorf("got %s", s)
+	}
+	if _, ok := store.Get("js"); ok {
+		t.Error("expected script fragment not to be stored")
+	}
+
+	// Fragments with tag errors aren't stored.
+	_, err := render([]Option{WithValidation(ValidateCollect)}, func(h *HtmlResponse) {
+		h.Cache(store, "bad", 0, ComponentFunc(func(h *HtmlResponse) {
+			h.Div()
+		}))
+	})
+	if err == nil {
+		t.Error("expected an error for an unclosed element")
+	}
+	if _, ok := store.Get("bad"); ok {
+		t.Error("expected bad fragment not to be stored")
+	}
+}
+
+func TestFragment(t *testing.T) {
+	page := ComponentFunc(func(h *HtmlResponse) {
+		h.Html().Body().
+			Header().Span(Id("count"), OutOfBand()).Content("2 items").Header_().
+			Main(Id("list")).
+			Ul().Li().Content("One").Li().Content("Two").Ul_().
+			P(Id("note"), OutOfBand()).Content("Inside").
+			Main_().
+			Body_().Html_()
+	})
+
+	r := httptest.NewRequest("GET", "/orders", nil)
+	r.Header.Set("HX-Request", "true")
+	r.Header.Set("HX-Target", "list")
+	w := httptest.NewRecorder()
+	err := RenderPage(w, r, page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<ul><li>One</li><li>Two</li></ul><p id="note" hx-swap-oob="true">Inside</p>` +
+		`<span id="count" hx-swap-oob="true">2 items</span>`
+	if w.Body.String() != expected {
+		t.Errorf("got %s, expected %s", w.Body.String(), expected)
+	}
+	if w.Header().Get("Vary") == "" {
+		t.Error("expected a Vary header")
+	}
+
+	// A full page load.
+	w = httptest.NewRecorder()
+	err = RenderPage(w, httptest.NewRequest("GET", "/orders", nil), page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(w.Body.String(), "<html><body><header>") {
+		t.Errorf("expected the full page, got %s", w.Body.String())
+	}
+
+	if id := FragmentFromRequest(httptest.NewRequest("GET", "/orders?fragment=list", nil)); id != "list" {
+		t.Errorf("expected list, got %q", id)
+	}
+
+	var buf bytes.Buffer
+	if RenderFragment(&buf, page, "missing") == nil {
+		t.Error("expected an error for a missing id")
+	}
+}
+
+func TestGeneratedFiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the generator")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("no go tool")
+	}
+
+	// Run the generator on a copy so that stale files in the tree are caught
+	// rather than overwritten.
+	dir := t.TempDir()
+	for _, name := range []string{"gen_elements.go", "spec/elements.txt", "spec/foreign_elements.txt", "spec/attributes.txt"} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755)
+		if err == nil {
+			err = os.WriteFile(filepath.Join(dir, name), data, 0644)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(goTool, "run", "gen_elements.go")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("generator failed: %s\n%s", err, out)
+	}
+
+	for _, name := range []string{"elements_gen.go", "foreign_gen.go", "attrs_gen.go"} {
+		generated, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		existing, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(generated, existing) {
+			t.Errorf("%s is out of date; run \"go generate\"", name)
+		}
+	}
+}
+
+func TestGeneratedTables(t *testing.T) {
+	for name, expected := range map[string]AttrInfo{
+		"abbr":                {Function: "Abbr"},
+		"allowfullscreen":     {Function: "AllowFullscreen", Boolean: true},
+		"blocking":            {Function: "Blocking"},
+		"imagesrcset":         {Function: "ImageSrcSet"},
+		"imagesizes":          {Function: "ImageSizes"},
+		"nomodule":            {Function: "NoModule", Boolean: true},
+		"popovertarget":       {Function: "PopoverTarget"},
+		"popovertargetaction": {Function: "PopoverTargetAction"},
+		"onpointerdown":       {Function: "OnPointerDown"},
+		"onauxclick":          {Function: "OnAuxClick"},
+	} {
+		info, ok := LookupAttr(name)
+		if !ok || info != expected {
+			t.Errorf("%s: got %+v, expected %+v", name, info, expected)
+		}
+	}
+
+	for _, name := range []string{"onpointerup", "onanimationend", "ontransitionend", "ontouchstart", "onbeforeinput", "onauxclick"} {
+		if !eventHandlerAttrs[name] {
+			t.Errorf("%s should be an event handler", name)
+		}
+	}
+
+	// A <dt> can only lose its end tag when a <dt> or <dd> follows.
+	out, err := render([]Option{WithOutputMode(OutputMinified)}, func(h *HtmlResponse) {
+		h.Dl().Dt().Text("a").Dt_().Dd().Text("b").Dd_().Dl_().
+			Table().Tr().Th(Abbr("n")).Text("Name").Th_().Tr_().Table_().
+			Script(NoModule(), Src("/old.js")).Script_()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<dl><dt>a</dt><dd>b</dl><table><tr><th abbr="n">Name</table>` +
+		`<script nomodule src="/old.js"></script>`
+	if out != expected {
+		t.Errorf("got\n%s\nexpected\n%s", out, expected)
+	}
+}
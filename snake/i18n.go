@@ -0,0 +1,27 @@
+package snake
+
+import (
+	"github.com/lkesteloot/goutil/snake/i18n"
+)
+
+// Translate T() calls with the catalog, and format their arguments for its
+// locale.
+func WithCatalog(catalog *i18n.Catalog) Option {
+	return func(h *HtmlResponse) {
+		h.catalog = catalog
+	}
+}
+
+// Return the catalog given with WithCatalog(), or nil. A nil catalog still
+// translates, using keys as messages and English formatting, which is
+// handy for attribute values:
+//
+//	h.Input(Placeholder(h.Catalog().Translate("search.placeholder")))
+func (h *HtmlResponse) Catalog() *i18n.Catalog {
+	return h.catalog
+}
+
+// Write the translated message for the key as text. See i18n.Catalog.Translate().
+func (h *HtmlResponse) T(key string, args ...interface{}) *HtmlResponse {
+	return h.Text(h.catalog.Translate(key, args...))
+}
@@ -0,0 +1,383 @@
+package i18n
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/lkesteloot/goutil/dbutil"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A translated message, either plain text or plural forms.
+type message struct {
+	text  string
+	forms map[PluralCategory]string
+}
+
+// The messages of one locale, by key. A nil catalog has no messages and
+// uses English formatting, so code written with T() works before anything
+// is translated.
+type Catalog struct {
+	locale   *Locale
+	messages map[string]*message
+}
+
+// Make an empty catalog for the locale.
+func NewCatalog(locale *Locale) *Catalog {
+	return &Catalog{
+		locale:   locale,
+		messages: make(map[string]*message),
+	}
+}
+
+// Return the catalog's locale.
+func (c *Catalog) Locale() *Locale {
+	if c == nil {
+		return English
+	}
+
+	return c.locale
+}
+
+// Add a message.
+func (c *Catalog) Add(key, text string) *Catalog {
+	c.messages[key] = &message{text: text}
+	return c
+}
+
+// Add a message with plural forms. Other is used for categories that aren't
+// given.
+func (c *Catalog) AddPlural(key string, forms map[PluralCategory]string) *Catalog {
+	c.messages[key] = &message{forms: forms}
+	return c
+}
+
+// Return whether the catalog has a message for the key.
+func (c *Catalog) Has(key string) bool {
+	if c == nil {
+		return false
+	}
+
+	_, ok := c.messages[key]
+	return ok
+}
+
+// Add the messages of a JSON object whose values are either strings or
+// objects of plural forms keyed by category ("one", "other", etc.).
+func (c *Catalog) LoadJSON(r io.Reader) error {
+	var entries map[string]json.RawMessage
+	err := json.NewDecoder(r).Decode(&entries)
+	if err != nil {
+		return err
+	}
+
+	for key, raw := range entries {
+		var text string
+		if json.Unmarshal(raw, &text) == nil {
+			c.Add(key, text)
+			continue
+		}
+
+		var forms map[PluralCategory]string
+		err = json.Unmarshal(raw, &forms)
+		if err != nil {
+			return fmt.Errorf("message %q: not a string or plural forms", key)
+		}
+		c.AddPlural(key, forms)
+	}
+
+	return nil
+}
+
+// Add the translated messages of a gettext PO file, using each msgid as
+// the key. The msgstr[n] forms of plural messages map to the plural
+// categories of the counts that the header's Plural-Forms expression picks
+// them for, or to the locale's PluralCategories in order if there's no
+// expression. Untranslated and fuzzy messages are skipped. Messages with a
+// msgctxt are an error, since keys have no context.
+func (c *Catalog) LoadPO(r io.Reader) error {
+	var id, field string
+	var text string
+	var forms map[int]string
+	var hasId bool
+	lineNo := 0
+
+	// Category of each msgstr[n].
+	categories := c.locale.PluralCategories
+
+	// Whether the entry being read, or the next one, is marked fuzzy.
+	var fuzzy, nextFuzzy bool
+
+	// Add the entry read so far.
+	flush := func() error {
+		var err error
+		switch {
+		case hasId && id == "":
+			categories, err = c.poCategories(text)
+		case !hasId || fuzzy:
+		case forms != nil:
+			if forms[0] != "" {
+				byCategory := make(map[PluralCategory]string)
+				for index, form := range forms {
+					if index < len(categories) && categories[index] != "" {
+						byCategory[categories[index]] = form
+					}
+				}
+				c.AddPlural(id, byCategory)
+			}
+		case text != "":
+			c.Add(id, text)
+		}
+		id, text, forms, hasId, fuzzy = "", "", nil, false, false
+		return err
+	}
+
+	// Append to the field being read.
+	appendTo := func(s string) error {
+		switch {
+		case field == "msgid":
+			id += s
+		case field == "msgstr":
+			text += s
+		case strings.HasPrefix(field, "msgstr["):
+			index, err := strconv.Atoi(strings.TrimSuffix(field[len("msgstr["):], "]"))
+			if err != nil {
+				return fmt.Errorf("line %d: bad field %s", lineNo, field)
+			}
+			if forms == nil {
+				forms = make(map[int]string)
+			}
+			forms[index] += s
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#,") {
+			for _, flag := range strings.Split(line[2:], ",") {
+				if strings.TrimSpace(flag) == "fuzzy" {
+					nextFuzzy = true
+				}
+			}
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// A continuation of the previous field.
+		if strings.HasPrefix(line, `"`) {
+			s, err := strconv.Unquote(line)
+			if err != nil {
+				return fmt.Errorf("line %d: bad string", lineNo)
+			}
+			err = appendTo(s)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		name, quoted, ok := strings.Cut(line, " ")
+		if !ok {
+			return fmt.Errorf("line %d: expected a keyword and a string", lineNo)
+		}
+		s, err := strconv.Unquote(strings.TrimSpace(quoted))
+		if err != nil {
+			return fmt.Errorf("line %d: bad string", lineNo)
+		}
+
+		switch {
+		case name == "msgctxt":
+			return fmt.Errorf("line %d: msgctxt isn't supported", lineNo)
+		case name == "msgid":
+			err = flush()
+			if err != nil {
+				return err
+			}
+			hasId = true
+			fuzzy, nextFuzzy = nextFuzzy, false
+			field = name
+		case name == "msgid_plural":
+			field = name
+		case name == "msgstr" || strings.HasPrefix(name, "msgstr["):
+			field = name
+		default:
+			return fmt.Errorf("line %d: unknown keyword %s", lineNo, name)
+		}
+
+		err = appendTo(s)
+		if err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return flush()
+}
+
+// Return the plural category of each msgstr[n], given a PO file's header.
+// Each n gets the category of the first count its Plural-Forms expression
+// picks it for. Without an expression, the locale's PluralCategories are
+// used in order.
+func (c *Catalog) poCategories(header string) ([]PluralCategory, error) {
+	var forms string
+	for _, line := range strings.Split(header, "\n") {
+		name, value, _ := strings.Cut(line, ":")
+		if strings.EqualFold(strings.TrimSpace(name), "Plural-Forms") {
+			forms = value
+		}
+	}
+
+	var expr, nplurals string
+	for _, field := range strings.Split(forms, ";") {
+		name, value, _ := strings.Cut(field, "=")
+		switch strings.TrimSpace(name) {
+		case "plural":
+			expr = value
+		case "nplurals":
+			nplurals = strings.TrimSpace(value)
+		}
+	}
+	if strings.TrimSpace(expr) == "" {
+		return c.locale.PluralCategories, nil
+	}
+
+	count, err := strconv.Atoi(nplurals)
+	if err != nil || count <= 0 {
+		return nil, fmt.Errorf("Plural-Forms: bad nplurals %q", nplurals)
+	}
+	plural, err := parsePluralExpr(expr)
+	if err != nil {
+		return nil, fmt.Errorf("Plural-Forms: %s", err)
+	}
+
+	categories := make([]PluralCategory, count)
+	for n := 0; n < 1000; n++ {
+		index := plural(n)
+		if index >= 0 && index < count && categories[index] == "" {
+			categories[index] = c.locale.Plural(n)
+		}
+	}
+
+	return categories, nil
+}
+
+// Return the message for the key with its arguments filled in. If the
+// message has plural forms, the first argument picks the form. If the key
+// isn't in the catalog, the key itself is used as the message.
+func (c *Catalog) Translate(key string, args ...interface{}) string {
+	text := key
+
+	if c != nil {
+		if m, ok := c.messages[key]; ok {
+			text = m.text
+			if m.forms != nil {
+				text = m.pluralForm(c.Locale(), args)
+				if text == "" {
+					text = key
+				}
+			}
+		}
+	}
+
+	return c.substitute(text, args)
+}
+
+// Return the form picked by the first argument, or the "other" form.
+func (m *message) pluralForm(locale *Locale, args []interface{}) string {
+	if len(args) > 0 {
+		if n, ok := toCount(args[0]); ok {
+			if form, ok := m.forms[locale.Plural(n)]; ok {
+				return form
+			}
+		}
+	}
+
+	return m.forms[Other]
+}
+
+// Return the argument as a count for picking a plural form.
+func toCount(arg interface{}) (int, bool) {
+	v := reflect.ValueOf(arg)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(v.Uint()), true
+	}
+
+	return 0, false
+}
+
+// Replace {0}, {1}, etc. with the formatted arguments. Other braces are
+// left alone.
+func (c *Catalog) substitute(text string, args []interface{}) string {
+	if len(args) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(text, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(text[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		index, err := strconv.Atoi(text[start+1 : end])
+		if err != nil || index < 0 || index >= len(args) {
+			b.WriteString(text[:start+1])
+			text = text[start+1:]
+			continue
+		}
+
+		b.WriteString(text[:start])
+		b.WriteString(c.Format(args[index]))
+		text = text[end+1:]
+	}
+	b.WriteString(text)
+
+	return b.String()
+}
+
+// Format a value for the locale: numbers with digit grouping, times as
+// dates, and money and percentages with the locale's separators.
+func (c *Catalog) Format(value interface{}) string {
+	l := c.Locale()
+
+	switch v := value.(type) {
+	case dbutil.Money:
+		return l.FormatMoney(v)
+	case dbutil.Percent:
+		return l.FormatPercent(v)
+	case time.Time:
+		return l.FormatDate(v)
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return l.FormatInt(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return l.FormatInt(int64(rv.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return l.FormatNumber(rv.Float(), -1)
+	}
+
+	return fmt.Sprint(value)
+}
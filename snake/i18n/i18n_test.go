@@ -0,0 +1,166 @@
+package i18n
+
+import (
+	"github.com/lkesteloot/goutil/dbutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPluralRules(t *testing.T) {
+	tests := []struct {
+		tag      string
+		n        int
+		expected PluralCategory
+	}{
+		{"en", 1, One},
+		{"en", 0, Other},
+		{"fr", 0, One},
+		{"ja", 1, Other},
+		{"ru", 21, One},
+		{"ru", 3, Few},
+		{"ru", 12, Many},
+		{"pl", 22, Few},
+		{"pl", 21, Many},
+		{"ar", 2, Two},
+		{"ar", 105, Few},
+	}
+
+	for _, test := range tests {
+		l, _ := LookupLocale(test.tag)
+		if c := l.Plural(test.n); c != test.expected {
+			t.Errorf("%s %d: got %s, expected %s", test.tag, test.n, c, test.expected)
+		}
+	}
+}
+
+func TestFormatting(t *testing.T) {
+	de, ok := LookupLocale("de_AT")
+	if !ok || de.Tag != "de" {
+		t.Fatal("no fallback to language")
+	}
+	if gb, ok := LookupLocale("en_gb"); !ok || gb.Tag != "en-GB" {
+		t.Error("tag lookup should ignore case")
+	}
+
+	date := time.Date(2024, 3, 5, 14, 7, 0, 0, time.UTC)
+	tests := []struct {
+		actual, expected string
+	}{
+		{English.FormatInt(-1234567), "-1,234,567"},
+		{de.FormatNumber(1234.5, 2), "1.234,50"},
+		{English.FormatNumber(-0.001, 2), "0.00"},
+		{de.FormatMoney(dbutil.NewMoney(-123456)), "-1.234,56 $"},
+		{English.FormatMoney(dbutil.NullMoney()), ""},
+		{English.FormatDate(date), "March 5, 2024"},
+		{de.FormatDateTime(date), "5. März 2024, 14:07"},
+		{locales["es"].FormatDate(date), "5 de marzo de 2024"},
+		{English.FormatPercent(dbutil.NewPercent(12.3)), "12.3%"},
+		{locales["fr"].FormatPercent(dbutil.NewPercent(1250)), "1\u202f250\u202f%"},
+		{English.FormatPercent(dbutil.NullPercent()), ""},
+		{NewCatalog(de).Format(dbutil.NewPercent(7.5)), "7,5\u00a0%"},
+	}
+
+	for _, test := range tests {
+		if test.actual != test.expected {
+			t.Errorf("got %q, expected %q", test.actual, test.expected)
+		}
+	}
+}
+
+func TestCatalogs(t *testing.T) {
+	ru, _ := LookupLocale("ru")
+	c := NewCatalog(ru)
+
+	err := c.LoadJSON(strings.NewReader(`{"title": "Корзина", "items": {"one": "{0} товар",
+		"few": "{0} товара", "many": "{0} товаров"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.LoadPO(strings.NewReader(`# Header.
+msgid ""
+msgstr ""
+"Plural-Forms: nplurals=3;\n"
+
+#: cart.go:10
+msgid "files"
+msgid_plural "files"
+msgstr[0] "{0} файл"
+msgstr[1] "{0} файла"
+msgstr[2] "{0} "
+"файлов"
+
+msgid "untranslated"
+msgstr ""
+
+#, fuzzy, c-format
+msgid "guess"
+msgstr "догадка"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		actual, expected string
+	}{
+		{c.Translate("title"), "Корзина"},
+		{c.Translate("items", 1), "1 товар"},
+		{c.Translate("items", 3), "3 товара"},
+		{c.Translate("items", 11), "11 товаров"},
+		{c.Translate("files", 1234), "1\u00a0234 файла"},
+		{c.Translate("files", 5), "5 файлов"},
+		{c.Translate("untranslated"), "untranslated"},
+		{c.Translate("guess"), "guess"},
+		{c.Translate("{1} of {0} {x}", 10, 2), "2 of 10 {x}"},
+		{(*Catalog)(nil).Translate("Total: {0}", dbutil.NewMoney(150000)), "Total: $1,500.00"},
+	}
+
+	for _, test := range tests {
+		if test.actual != test.expected {
+			t.Errorf("got %q, expected %q", test.actual, test.expected)
+		}
+	}
+
+	err = c.LoadPO(strings.NewReader("msgctxt \"menu\"\nmsgid \"Open\"\nmsgstr \"Открыть\"\n"))
+	if err == nil {
+		t.Error("expected an error for msgctxt")
+	}
+}
+
+func TestPluralForms(t *testing.T) {
+	// The forms follow the header's expression, not the locale's order, and
+	// the fourth form, for fractions, is never picked for a whole number.
+	ru, _ := LookupLocale("ru")
+	c := NewCatalog(ru)
+	err := c.LoadPO(strings.NewReader(`msgid ""
+msgstr ""
+"Plural-Forms: nplurals=4; plural=(n%1!=0 ? 3 : n%10==1 && n%100!=11 ? 1 : "
+"n%10>=2 && n%10<=4 && (n%100<12 || n%100>14) ? 2 : 0);\n"
+
+msgid "files"
+msgid_plural "files"
+msgstr[0] "{0} файлов"
+msgstr[1] "{0} файл"
+msgstr[2] "{0} файла"
+msgstr[3] "{0} файла"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for n, expected := range map[int]string{1: "1 файл", 3: "3 файла", 5: "5 файлов", 21: "21 файл", 12: "12 файлов"} {
+		if actual := c.Translate("files", n); actual != expected {
+			t.Errorf("got %q, expected %q", actual, expected)
+		}
+	}
+
+	for _, header := range []string{"nplurals=2; plural=n>;", "nplurals=x; plural=n!=1;", "plural=(n;"} {
+		err := NewCatalog(English).LoadPO(strings.NewReader(
+			"msgid \"\"\nmsgstr \"Plural-Forms: " + header + "\\n\"\n"))
+		if err == nil {
+			t.Errorf("%q: expected an error", header)
+		}
+	}
+}
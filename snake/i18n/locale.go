@@ -0,0 +1,277 @@
+// Translation and locale formatting for snake pages.
+//
+// A Catalog holds the messages of one locale, loaded from JSON or gettext
+// PO files. Pass it to snake.WithCatalog() and write messages with
+// h.T(key, args...). Messages refer to their arguments as {0}, {1}, etc.,
+// and a message with plural forms picks the form by its first argument:
+//
+//	{
+//	  "cart.title": "Your cart",
+//	  "cart.items": {"one": "{0} item", "other": "{0} items"}
+//	}
+//
+// Numbers, times, and dbutil.Money and dbutil.Percent arguments are
+// formatted for the locale.
+// Extract the keys used in Go source with cmd/i18nextract.
+package i18n
+
+import (
+	"github.com/lkesteloot/goutil/dbutil"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How a language and region writes numbers, money, and dates.
+type Locale struct {
+	// BCP 47 tag, such as "en-US".
+	Tag string
+
+	// Decimal point and digit group separator. Spaces in separators are
+	// non-breaking.
+	Decimal string
+	Group   string
+
+	// Money format, with "#" for the amount, such as "$#" or "# $".
+	// dbutil.Money is always in dollars.
+	MoneyPattern string
+
+	// Percentage format, with "#" for the number, such as "#%" or "# %".
+	PercentPattern string
+
+	// Date formats. "d" and "dd" are the day, "M" and "MM" the month
+	// number, "MMMM" the month name, "yyyy" the year, and "HH" and "mm" the
+	// hour and minute. Other letters are written as-is.
+	DatePattern     string
+	DateTimePattern string
+
+	// Month names, January first.
+	Months []string
+
+	Plural PluralRule
+
+	// Plural categories that whole numbers can have, in the order used by
+	// gettext's msgstr[n].
+	PluralCategories []PluralCategory
+}
+
+var englishMonths = []string{"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December"}
+
+// Built-in locales, by tag.
+var locales = map[string]*Locale{
+	"en": {
+		Tag: "en", Decimal: ".", Group: ",", MoneyPattern: "$#", PercentPattern: "#%",
+		DatePattern: "MMMM d, yyyy", DateTimePattern: "MMMM d, yyyy HH:mm",
+		Months: englishMonths,
+		Plural: pluralOneOther, PluralCategories: []PluralCategory{One, Other},
+	},
+	"en-GB": {
+		Tag: "en-GB", Decimal: ".", Group: ",", MoneyPattern: "$#", PercentPattern: "#%",
+		DatePattern: "d MMMM yyyy", DateTimePattern: "d MMMM yyyy HH:mm",
+		Months: englishMonths,
+		Plural: pluralOneOther, PluralCategories: []PluralCategory{One, Other},
+	},
+	"de": {
+		Tag: "de", Decimal: ",", Group: ".", MoneyPattern: "# $", PercentPattern: "#\u00a0%",
+		DatePattern: "d. MMMM yyyy", DateTimePattern: "d. MMMM yyyy, HH:mm",
+		Months: []string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember"},
+		Plural: pluralOneOther, PluralCategories: []PluralCategory{One, Other},
+	},
+	"es": {
+		Tag: "es", Decimal: ",", Group: ".", MoneyPattern: "# $", PercentPattern: "#\u00a0%",
+		DatePattern: "d 'de' MMMM 'de' yyyy", DateTimePattern: "d 'de' MMMM 'de' yyyy, HH:mm",
+		Months: []string{"enero", "febrero", "marzo", "abril", "mayo", "junio",
+			"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		Plural: pluralOneOther, PluralCategories: []PluralCategory{One, Other},
+	},
+	"fr": {
+		Tag: "fr", Decimal: ",", Group: "\u202f", MoneyPattern: "# $", PercentPattern: "#\u202f%",
+		DatePattern: "d MMMM yyyy", DateTimePattern: "d MMMM yyyy HH:mm",
+		Months: []string{"janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		Plural: pluralZeroOneOther, PluralCategories: []PluralCategory{One, Other},
+	},
+	"ja": {
+		Tag: "ja", Decimal: ".", Group: ",", MoneyPattern: "$#", PercentPattern: "#%",
+		DatePattern: "yyyy年M月d日", DateTimePattern: "yyyy年M月d日 HH:mm",
+		Months: []string{"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月"},
+		Plural: pluralOther, PluralCategories: []PluralCategory{Other},
+	},
+	"pl": {
+		Tag: "pl", Decimal: ",", Group: "\u00a0", MoneyPattern: "# $", PercentPattern: "#%",
+		DatePattern: "d MMMM yyyy", DateTimePattern: "d MMMM yyyy HH:mm",
+		Months: []string{"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca",
+			"lipca", "sierpnia", "września", "października", "listopada", "grudnia"},
+		Plural: pluralPolish, PluralCategories: []PluralCategory{One, Few, Many},
+	},
+	"ru": {
+		Tag: "ru", Decimal: ",", Group: "\u00a0", MoneyPattern: "# $", PercentPattern: "#\u00a0%",
+		DatePattern: "d MMMM yyyy", DateTimePattern: "d MMMM yyyy, HH:mm",
+		Months: []string{"января", "февраля", "марта", "апреля", "мая", "июня",
+			"июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		Plural: pluralEastSlavic, PluralCategories: []PluralCategory{One, Few, Many},
+	},
+	"ar": {
+		Tag: "ar", Decimal: ".", Group: ",", MoneyPattern: "# $", PercentPattern: "#%",
+		DatePattern: "d MMMM yyyy", DateTimePattern: "d MMMM yyyy HH:mm",
+		Months: []string{"يناير", "فبراير", "مارس", "أبريل", "مايو", "يونيو",
+			"يوليو", "أغسطس", "سبتمبر", "أكتوبر", "نوفمبر", "ديسمبر"},
+		Plural: pluralArabic, PluralCategories: []PluralCategory{Zero, One, Two, Few, Many, Other},
+	},
+}
+
+// The locale used when none is given.
+var English = locales["en"]
+
+// Return the built-in locale for the tag, such as "fr-CA". If there's no
+// locale for the whole tag, the one for its language is used.
+func LookupLocale(tag string) (*Locale, bool) {
+	tag = strings.Replace(tag, "_", "-", -1)
+	for key, l := range locales {
+		if strings.EqualFold(key, tag) {
+			return l, true
+		}
+	}
+
+	language, _, _ := strings.Cut(tag, "-")
+	l, ok := locales[strings.ToLower(language)]
+	return l, ok
+}
+
+// Format the integer with digit grouping.
+func (l *Locale) FormatInt(n int64) string {
+	s := strconv.FormatInt(n, 10)
+
+	sign := ""
+	if n < 0 {
+		sign, s = "-", s[1:]
+	}
+
+	return sign + l.group(s)
+}
+
+// Format the number with digit grouping and the given number of decimals.
+func (l *Locale) FormatNumber(f float64, decimals int) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	s := strconv.FormatFloat(math.Abs(f), 'f', decimals, 64)
+	whole, fraction, _ := strings.Cut(s, ".")
+
+	sign := ""
+	if f < 0 && strings.Trim(s, "0.") != "" {
+		sign = "-"
+	}
+	s = sign + l.group(whole)
+	if fraction != "" {
+		s += l.Decimal + fraction
+	}
+
+	return s
+}
+
+// Insert group separators into a string of digits.
+func (l *Locale) group(digits string) string {
+	var b strings.Builder
+
+	for i, ch := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteRune(ch)
+	}
+
+	return b.String()
+}
+
+// Format money, or return "" for null money.
+func (l *Locale) FormatMoney(m dbutil.Money) string {
+	if m.IsNull {
+		return ""
+	}
+
+	amount := l.FormatNumber(math.Abs(float64(m.Pennies))/100, 2)
+	s := strings.Replace(l.MoneyPattern, "#", amount, 1)
+	if m.Pennies < 0 {
+		s = "-" + s
+	}
+
+	return s
+}
+
+// Format the percentage, or return "" for a null one.
+func (l *Locale) FormatPercent(p dbutil.Percent) string {
+	if p.IsNull {
+		return ""
+	}
+
+	// Go through the float32's shortest text, so that 12.3 isn't
+	// 12.300000190734863.
+	value, _ := strconv.ParseFloat(strconv.FormatFloat(float64(p.Value), 'g', -1, 32), 64)
+	return strings.Replace(l.PercentPattern, "#", l.FormatNumber(value, -1), 1)
+}
+
+// Format the date of the time.
+func (l *Locale) FormatDate(t time.Time) string {
+	return l.formatTime(t, l.DatePattern)
+}
+
+// Format the date and time of the time.
+func (l *Locale) FormatDateTime(t time.Time) string {
+	return l.formatTime(t, l.DateTimePattern)
+}
+
+// Format the time with a pattern like DatePattern. Text in single quotes is
+// written as-is.
+func (l *Locale) formatTime(t time.Time, pattern string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(pattern); {
+		ch := pattern[i]
+
+		if ch == '\'' {
+			end := strings.IndexByte(pattern[i+1:], '\'')
+			if end < 0 {
+				end = len(pattern) - i - 1
+			}
+			b.WriteString(pattern[i+1 : i+1+end])
+			i += end + 2
+			continue
+		}
+
+		// Length of the run of the same letter.
+		n := 1
+		for i+n < len(pattern) && pattern[i+n] == ch {
+			n++
+		}
+
+		switch {
+		case ch == 'd' && n == 1:
+			b.WriteString(strconv.Itoa(t.Day()))
+		case ch == 'd':
+			b.WriteString(t.Format("02"))
+		case ch == 'M' && n == 1:
+			b.WriteString(strconv.Itoa(int(t.Month())))
+		case ch == 'M' && n == 2:
+			b.WriteString(t.Format("01"))
+		case ch == 'M':
+			b.WriteString(l.Months[t.Month()-1])
+		case ch == 'y':
+			b.WriteString(strconv.Itoa(t.Year()))
+		case ch == 'H':
+			b.WriteString(t.Format("15"))
+		case ch == 'm':
+			b.WriteString(t.Format("04"))
+		default:
+			b.WriteString(pattern[i : i+n])
+		}
+		i += n
+	}
+
+	return b.String()
+}
@@ -0,0 +1,282 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CLDR plural categories.
+type PluralCategory string
+
+const (
+	Zero  PluralCategory = "zero"
+	One   PluralCategory = "one"
+	Two   PluralCategory = "two"
+	Few   PluralCategory = "few"
+	Many  PluralCategory = "many"
+	Other PluralCategory = "other"
+)
+
+// Picks the plural category of a count, following the CLDR rules for a
+// language. Only whole numbers are handled.
+type PluralRule func(n int) PluralCategory
+
+// Languages like English: "1 item", "2 items".
+func pluralOneOther(n int) PluralCategory {
+	if n == 1 {
+		return One
+	}
+	return Other
+}
+
+// Languages like French, where zero is singular too.
+func pluralZeroOneOther(n int) PluralCategory {
+	if n == 0 || n == 1 {
+		return One
+	}
+	return Other
+}
+
+// Languages like Japanese, with no plural forms.
+func pluralOther(n int) PluralCategory {
+	return Other
+}
+
+// Russian and Ukrainian.
+func pluralEastSlavic(n int) PluralCategory {
+	n = abs(n)
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return One
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return Few
+	}
+	return Many
+}
+
+// Polish.
+func pluralPolish(n int) PluralCategory {
+	n = abs(n)
+	switch {
+	case n == 1:
+		return One
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return Few
+	}
+	return Many
+}
+
+// Arabic.
+func pluralArabic(n int) PluralCategory {
+	n = abs(n)
+	switch {
+	case n == 0:
+		return Zero
+	case n == 1:
+		return One
+	case n == 2:
+		return Two
+	case n%100 >= 3 && n%100 <= 10:
+		return Few
+	case n%100 >= 11:
+		return Many
+	}
+	return Other
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Binary operators of gettext plural expressions, loosest first. Longer
+// operators come before their prefixes.
+var pluralOperators = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<=", ">=", "<", ">"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// Parser of the C-like expressions in a gettext Plural-Forms header, such as
+// "n%10==1 && n%100!=11 ? 0 : 1".
+type pluralParser struct {
+	s   string
+	pos int
+}
+
+// Parse the plural expression into a function that returns the msgstr index
+// for a count.
+func parsePluralExpr(s string) (func(n int) int, error) {
+	p := &pluralParser{s: s}
+	f, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return nil, fmt.Errorf("unexpected %q in plural expression", p.s[p.pos:])
+	}
+
+	return f, nil
+}
+
+func (p *pluralParser) skipSpace() {
+	for p.pos < len(p.s) && strings.ContainsRune(" \t\n\r", rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// Consume the token if it's next.
+func (p *pluralParser) accept(token string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.s[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *pluralParser) ternary() (func(n int) int, error) {
+	cond, err := p.binary(0)
+	if err != nil || !p.accept("?") {
+		return cond, err
+	}
+
+	yes, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept(":") {
+		return nil, fmt.Errorf("missing : in plural expression")
+	}
+	no, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(n int) int {
+		if cond(n) != 0 {
+			return yes(n)
+		}
+		return no(n)
+	}, nil
+}
+
+// Parse operands joined by the operators of pluralOperators[level] or
+// tighter ones.
+func (p *pluralParser) binary(level int) (func(n int) int, error) {
+	if level == len(pluralOperators) {
+		return p.unary()
+	}
+
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := ""
+		for _, candidate := range pluralOperators[level] {
+			if p.accept(candidate) {
+				op = candidate
+				break
+			}
+		}
+		if op == "" {
+			return left, nil
+		}
+
+		right, err := p.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = pluralOperation(op, left, right)
+	}
+}
+
+func (p *pluralParser) unary() (func(n int) int, error) {
+	switch {
+	case p.accept("!"):
+		f, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(n int) int { return boolInt(f(n) == 0) }, nil
+	case p.accept("("):
+		f, err := p.ternary()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("missing ) in plural expression")
+		}
+		return f, nil
+	case p.accept("n"):
+		return func(n int) int { return n }, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+		p.pos++
+	}
+	value, err := strconv.Atoi(p.s[start:p.pos])
+	if err != nil {
+		return nil, fmt.Errorf("expected a number in plural expression at %q", p.s[start:])
+	}
+	return func(n int) int { return value }, nil
+}
+
+// Return the function that applies the binary operator.
+func pluralOperation(op string, left, right func(n int) int) func(n int) int {
+	return func(n int) int {
+		a, b := left(n), right(n)
+		switch op {
+		case "||":
+			return boolInt(a != 0 || b != 0)
+		case "&&":
+			return boolInt(a != 0 && b != 0)
+		case "==":
+			return boolInt(a == b)
+		case "!=":
+			return boolInt(a != b)
+		case "<=":
+			return boolInt(a <= b)
+		case ">=":
+			return boolInt(a >= b)
+		case "<":
+			return boolInt(a < b)
+		case ">":
+			return boolInt(a > b)
+		case "+":
+			return a + b
+		case "-":
+			return a - b
+		case "*":
+			return a * b
+		case "/":
+			if b == 0 {
+				return 0
+			}
+			return a / b
+		case "%":
+			if b == 0 {
+				return 0
+			}
+			return a % b
+		}
+		panic("unknown operator " + op)
+	}
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
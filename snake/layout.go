@@ -0,0 +1,301 @@
+package snake
+
+import (
+	"bytes"
+)
+
+// Conventional block names. Layouts may define any others they like.
+const (
+	BlockTitle   = "title"
+	BlockHead    = "head"
+	BlockContent = "content"
+	BlockScripts = "scripts"
+)
+
+// Named components that fill the blocks of a layout.
+type Blocks map[string]Component
+
+// A page skeleton with named blocks that pages (or other layouts) fill in.
+// The skeleton calls h.Block(name) wherever a block goes:
+//
+//	base := NewLayout(ComponentFunc(func(h *HtmlResponse) {
+//		h.Doctype().Html().
+//			Head().Title().Block(BlockTitle).Title_().Block(BlockHead).Head_().
+//			Body().Block(BlockContent).Block(BlockScripts).Body_().
+//			Html_()
+//	}), Blocks{BlockTitle: TextComponent("My Site")})
+//
+// While a layout renders, StyleLink() and ScriptLink() calls made outside the
+// <head> are hoisted: stylesheets move to the end of the head, and scripts
+// move to the start of the scripts block (or the end of the body if there
+// isn't one). Each URL is only linked once per page, and the layout as a
+// whole is buffered until it's complete, except that automatic flush points
+// (WithFlushAfterHead() and WithFlushEveryRows()) write out and flush what's
+// been rendered once the <head> is done.
+type Layout struct {
+	parent   *Layout
+	skeleton Component
+	blocks   Blocks
+}
+
+// Make a base layout. The defaults fill blocks that pages don't provide.
+func NewLayout(skeleton Component, defaults Blocks) *Layout {
+	return &Layout{
+		skeleton: skeleton,
+		blocks:   defaults,
+	}
+}
+
+// Make a layout that uses l's skeleton but fills or overrides some of its
+// blocks, such as an admin layout that adds a navigation bar.
+func (l *Layout) Extend(blocks Blocks) *Layout {
+	return &Layout{
+		parent: l,
+		blocks: blocks,
+	}
+}
+
+// Where hoisted links go.
+type hoistTarget int
+
+const (
+	hoistNone hoistTarget = iota
+	hoistHead
+	hoistFooter
+)
+
+// Part of a rendered layout: either finished bytes or a spot for hoisted links.
+type layoutSegment struct {
+	data   []byte
+	target hoistTarget
+}
+
+// State of a layout while it renders.
+type layoutState struct {
+	blocks   Blocks
+	segments []layoutSegment
+	buf      bytes.Buffer
+
+	// Hoisted URLs, in the order they were first seen.
+	styles  []string
+	scripts []string
+
+	// URLs already linked or hoisted.
+	seen map[string]bool
+
+	// Whether the spot for each hoist target has been placed.
+	placed map[hoistTarget]bool
+
+	// When building a tree, the node marking each spot.
+	spots map[hoistTarget]*Node
+
+	// When writing markup, the response the segments go to, and whether the
+	// <head> has been written to it by an automatic flush.
+	out         *HtmlResponse
+	headWritten bool
+}
+
+// Render the page using the layout. Blocks given here override those of the
+// layout and its parents.
+func (h *HtmlResponse) Layout(l *Layout, blocks Blocks) *HtmlResponse {
+	state := &layoutState{
+		blocks: make(Blocks),
+		seen:   make(map[string]bool),
+		placed: make(map[hoistTarget]bool),
+		spots:  make(map[hoistTarget]*Node),
+	}
+
+	// Most specific blocks win.
+	for name, c := range blocks {
+		state.blocks[name] = c
+	}
+	root := l
+	for ; l != nil; l = l.parent {
+		for name, c := range l.blocks {
+			if _, ok := state.blocks[name]; !ok {
+				state.blocks[name] = c
+			}
+		}
+		root = l
+	}
+
+	// When building a tree, render straight into it and put the hoisted
+	// links in at the end.
+	if h.tree != nil {
+		saved := h.layout
+		h.layout = state
+		h.Render(root.skeleton)
+		state.place(h, hoistHead)
+		state.place(h, hoistFooter)
+		h.layout = saved
+		state.fillSpots(h)
+		return h
+	}
+
+	// Render everything into segments, leaving spots for hoisted links.
+	state.out = h
+	sub := h.sub(&state.buf)
+	sub.layout = state
+	sub.Render(root.skeleton)
+	sub.Close()
+	state.place(sub, hoistHead)
+	state.place(sub, hoistFooter)
+	state.endSegment(sub, hoistNone)
+	h.tagErrors = append(h.tagErrors, sub.tagErrors...)
+
+	// Now that all links are known, write the rest out.
+	state.writeSegments(true)
+
+	return h
+}
+
+// Write the segments to the response, up to the footer spot unless all is
+// true, since scripts may still be hoisted to it.
+func (s *layoutState) writeSegments(all bool) {
+	for len(s.segments) > 0 {
+		segment := s.segments[0]
+		if !all && segment.target == hoistFooter {
+			return
+		}
+
+		// Already seen by the linter when it was rendered into the segment.
+		s.out.WriteStr(string(segment.data))
+		s.writeHoisted(s.out, segment.target)
+		if segment.target == hoistHead {
+			s.headWritten = true
+		}
+		s.segments = s.segments[1:]
+	}
+}
+
+// At an automatic flush point, write out and flush what's been rendered so
+// far. Nothing is written until the <head> is complete, and after that,
+// stylesheets can't be hoisted to it.
+func (s *layoutState) flush(h *HtmlResponse) {
+	if s.out == nil || !s.placed[hoistHead] {
+		h.Flush()
+		return
+	}
+
+	s.endSegment(h, hoistNone)
+	s.writeSegments(false)
+	s.out.autoFlush()
+}
+
+// Write the links hoisted to the target.
+func (s *layoutState) writeHoisted(h *HtmlResponse, target hoistTarget) {
+	switch target {
+	case hoistHead:
+		for _, url := range s.styles {
+			h.styleTag(url)
+		}
+	case hoistFooter:
+		for _, url := range s.scripts {
+			h.scriptTag(url)
+		}
+	}
+}
+
+// Replace the spot nodes of h's tree with the hoisted links.
+func (s *layoutState) fillSpots(h *HtmlResponse) {
+	for target, spot := range s.spots {
+		t := NewTree()
+		t.assets = h.assets
+		t.nonce = h.nonce
+		s.writeHoisted(t, target)
+		for _, n := range append([]*Node(nil), t.Tree().Children...) {
+			spot.Parent.InsertBefore(n, spot)
+		}
+		spot.Remove()
+	}
+}
+
+// Render the named block of the current layout. Does nothing if the block
+// isn't filled or if no layout is being rendered.
+func (h *HtmlResponse) Block(name string) *HtmlResponse {
+	if h.layout == nil {
+		return h
+	}
+
+	if name == BlockScripts {
+		h.layout.place(h, hoistFooter)
+	}
+
+	return h.Render(h.layout.blocks[name])
+}
+
+// Place hoisted links before the page's </head> and </body>.
+func (s *layoutState) beforeClose(h *HtmlResponse, tag string) {
+	switch tag {
+	case "head":
+		s.place(h, hoistHead)
+	case "body":
+		s.place(h, hoistFooter)
+	}
+}
+
+// Finish the current segment, ending it with a spot for hoisted links.
+func (s *layoutState) endSegment(h *HtmlResponse, target hoistTarget) {
+	h.Flush()
+	s.segments = append(s.segments, layoutSegment{
+		data:   append([]byte(nil), s.buf.Bytes()...),
+		target: target,
+	})
+	s.buf.Reset()
+}
+
+// Place the spot for the hoist target here, unless it's already been placed.
+func (s *layoutState) place(h *HtmlResponse, target hoistTarget) {
+	if s.placed[target] {
+		return
+	}
+	s.placed[target] = true
+
+	if h.tree != nil {
+		// Not a text node, so that text isn't merged into it.
+		spot := &Node{Type: DocumentNode}
+		h.tree.add(spot)
+		s.spots[target] = spot
+	} else {
+		s.endSegment(h, target)
+	}
+}
+
+// Note that the URL is linked. Returns true if it's the first time.
+func (s *layoutState) firstSighting(url string) bool {
+	if s.seen[url] {
+		return false
+	}
+	s.seen[url] = true
+	return true
+}
+
+// Handle a stylesheet link. Returns true if it should be written in place.
+func (s *layoutState) hoistStyle(h *HtmlResponse, url string) bool {
+	if !s.firstSighting(url) {
+		return false
+	}
+	if h.isOpen("head") {
+		return true
+	}
+
+	if s.headWritten {
+		return true
+	}
+
+	s.styles = append(s.styles, url)
+	return false
+}
+
+// Handle a script link. Returns true if it should be written in place.
+func (s *layoutState) hoistScript(h *HtmlResponse, url string) bool {
+	if !s.firstSighting(url) {
+		return false
+	}
+	if h.isOpen("head") {
+		return true
+	}
+
+	s.scripts = append(s.scripts, url)
+	return false
+}
@@ -0,0 +1,233 @@
+package snake
+
+import (
+	"fmt"
+	"strings"
+)
+
+// An accessibility problem found by Lint() or WithLint(), with the path of
+// the element where it was found.
+type LintError struct {
+	Message string
+
+	// The element, such as "html > body > div#main > img".
+	Path string
+}
+
+func (e *LintError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+
+	return e.Message + " at " + e.Path
+}
+
+// Check the page for common accessibility problems when the response is
+// closed, and return them from Close() and Err() as *LintError. Meant for
+// development and tests, since it keeps a copy of the page in memory.
+func WithLint() Option {
+	return func(h *HtmlResponse) {
+		root := &Node{Type: DocumentNode}
+		h.lint = &treeBuilder{
+			root:    root,
+			current: root,
+		}
+	}
+}
+
+// Check the page written so far and record its problems.
+func (h *HtmlResponse) reportLintErrors() {
+	root := h.lint.root
+	if h.tree != nil {
+		root = h.tree.root
+	}
+
+	for _, e := range Lint(root) {
+		h.tagErrors = append(h.tagErrors, e)
+	}
+}
+
+// Input types that don't need a label.
+var unlabeledInputTypes = map[string]bool{
+	"hidden": true,
+	"submit": true,
+	"reset":  true,
+	"button": true,
+	"image":  true,
+}
+
+// Check the tree for common WCAG problems: images without alt text,
+// duplicate ids, skipped heading levels, links and buttons without text,
+// form fields without labels, tables without header cells, and a missing
+// lang attribute on <html>.
+func Lint(root *Node) []*LintError {
+	var errors []*LintError
+	report := func(n *Node, format string, args ...interface{}) {
+		errors = append(errors, &LintError{
+			Message: fmt.Sprintf(format, args...),
+			Path:    nodePath(n),
+		})
+	}
+
+	// Ids, and the ids that labels point to.
+	ids := make(map[string]bool)
+	labeled := make(map[string]bool)
+	for _, label := range root.FindByTag("label") {
+		if id, ok := label.Attr("for"); ok {
+			labeled[id] = true
+		}
+	}
+
+	previousHeading := 0
+	root.Walk(func(n *Node) bool {
+		if n.Type != ElementNode {
+			return true
+		}
+
+		if id, ok := n.Attr("id"); ok && id != "" {
+			if ids[id] {
+				report(n, "duplicate id %q", id)
+			}
+			ids[id] = true
+		}
+
+		switch n.Tag {
+		case "html":
+			if lang, _ := n.Attr("lang"); strings.TrimSpace(lang) == "" {
+				report(n, "<html> has no lang attribute")
+			}
+
+		case "img":
+			if _, ok := n.Attr("alt"); !ok && !isHidden(n) {
+				report(n, "<img> has no alt attribute")
+			}
+
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			level := int(n.Tag[1] - '0')
+			if previousHeading > 0 && level > previousHeading+1 {
+				report(n, "heading skips from <h%d> to <h%d>", previousHeading, level)
+			}
+			previousHeading = level
+
+		case "a":
+			if _, ok := n.Attr("href"); ok && !hasAccessibleName(n) {
+				report(n, "link has no text")
+			}
+
+		case "button":
+			if !hasAccessibleName(n) {
+				report(n, "button has no text")
+			}
+
+		case "input", "select", "textarea":
+			typ, _ := n.Attr("type")
+			typ = strings.ToLower(typ)
+			if n.Tag == "input" && typ == "image" {
+				if _, ok := n.Attr("alt"); !ok {
+					report(n, "image button has no alt attribute")
+				}
+			}
+			if n.Tag == "input" && unlabeledInputTypes[typ] {
+				break
+			}
+			id, _ := n.Attr("id")
+			if !labeled[id] && !hasAncestor(n, "label") && !hasAriaLabel(n) {
+				report(n, "form field has no label")
+			}
+
+		case "table":
+			if !hasHeaderCell(n) && !isPresentation(n) {
+				report(n, "table has no header cells")
+			}
+
+		case "svg", "math":
+			// Foreign content has its own rules.
+			return false
+		}
+
+		return true
+	})
+
+	return errors
+}
+
+// Return whether the table's own rows have a <th>, not counting tables
+// inside it.
+func hasHeaderCell(table *Node) bool {
+	for _, tr := range tableRows(table) {
+		for _, cell := range tr.Children {
+			if cell.Type == ElementNode && cell.Tag == "th" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Return the element's path, like the paths of tag errors.
+func nodePath(n *Node) string {
+	var parts []string
+	for ; n != nil && n.Type == ElementNode; n = n.Parent {
+		parts = append([]string{newElement(n.Tag, n.attrs).String()}, parts...)
+	}
+
+	return strings.Join(parts, " > ")
+}
+
+func hasAncestor(n *Node, tag string) bool {
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p.Type == ElementNode && p.Tag == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Return whether the element is labeled with ARIA attributes or a title.
+func hasAriaLabel(n *Node) bool {
+	for _, name := range []string{"aria-label", "aria-labelledby", "title"} {
+		if value, _ := n.Attr(name); strings.TrimSpace(value) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Return whether the element has text that a screen reader would announce:
+// its own text, the alt text of images in it, or an ARIA label.
+func hasAccessibleName(n *Node) bool {
+	if hasAriaLabel(n) {
+		return true
+	}
+
+	found := false
+	n.Walk(func(c *Node) bool {
+		switch {
+		case c.Type == TextNode || c.Type == RawNode:
+			found = found || strings.TrimSpace(c.Text) != ""
+		case c.Type == ElementNode && c.Tag == "img":
+			alt, _ := c.Attr("alt")
+			found = found || strings.TrimSpace(alt) != ""
+		case c != n && c.Type == ElementNode && hasAriaLabel(c):
+			found = true
+		}
+		return !found
+	})
+
+	return found
+}
+
+// Return whether the element is hidden from screen readers.
+func isHidden(n *Node) bool {
+	hidden, _ := n.Attr("aria-hidden")
+	return hidden == "true" || isPresentation(n)
+}
+
+// Return whether the element is marked as being for layout only.
+func isPresentation(n *Node) bool {
+	role, _ := n.Attr("role")
+	return role == "presentation" || role == "none"
+}
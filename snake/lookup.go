@@ -0,0 +1,56 @@
+package snake
+
+// How snake writes an element, for tools that generate snake code.
+type ElementInfo struct {
+	// Name of the HtmlResponse method that opens or writes the element. Unless
+	// Void or SelfClosing, there's also a closing method with a trailing
+	// underscore.
+	Method string
+
+	// HTML element with no closing tag.
+	Void bool
+
+	// SVG or MathML element written as "<tag/>".
+	SelfClosing bool
+
+	// HTML element that pretty-printing puts on its own line, so that
+	// whitespace around it doesn't matter.
+	Block bool
+}
+
+// Return how snake writes the element. If foreign is true, the element is
+// inside <svg> or <math>, and those vocabularies are checked first.
+func LookupElement(tag string, foreign bool) (ElementInfo, bool) {
+	if foreign {
+		if info, ok := foreignElementInfos[tag]; ok {
+			return info, true
+		}
+	}
+
+	if info, ok := elementInfos[tag]; ok {
+		return info, true
+	}
+
+	// The roots of the foreign vocabularies are allowed anywhere.
+	if tag == "svg" || tag == "math" {
+		return foreignElementInfos[tag], true
+	}
+
+	return ElementInfo{}, false
+}
+
+// How snake makes an attribute, for tools that generate snake code.
+type AttrInfo struct {
+	// Name of the function that makes the attribute.
+	Function string
+
+	// Whether the function takes no value, because the attribute's presence
+	// means true.
+	Boolean bool
+}
+
+// Return how snake makes the attribute.
+func LookupAttr(name string) (AttrInfo, bool) {
+	info, ok := attrInfos[name]
+	return info, ok
+}
@@ -0,0 +1,64 @@
+package snake
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+)
+
+// Write the component as a MIME multipart/alternative entity with a
+// text/plain part (see RenderText()) and a text/html part, for email. The
+// MIME-Version and Content-Type headers are written first, so write the
+// message's other headers (From, To, Subject) to w before calling this.
+func WriteMultipartAlternative(w io.Writer, c Component, options ...Option) error {
+	text, err := RenderText(c, options...)
+	if err != nil {
+		return err
+	}
+
+	var html bytes.Buffer
+	h := New(&html, options...)
+	h.Render(c)
+	err = h.Close()
+	if err != nil {
+		return err
+	}
+
+	mw := multipart.NewWriter(w)
+	_, err = fmt.Fprintf(w, "MIME-Version: 1.0\r\nContent-Type: multipart/alternative; boundary=%s\r\n\r\n",
+		mw.Boundary())
+	if err != nil {
+		return err
+	}
+
+	// Clients show the last part they understand, so HTML goes last.
+	for _, part := range []struct {
+		contentType string
+		body        []byte
+	}{
+		{"text/plain; charset=utf-8", []byte(text)},
+		{"text/html; charset=utf-8", html.Bytes()},
+	} {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+		pw, err := mw.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		qw := quotedprintable.NewWriter(pw)
+		_, err = qw.Write(part.body)
+		if err == nil {
+			err = qw.Close()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return mw.Close()
+}
@@ -0,0 +1,59 @@
+package snake
+
+import (
+	"strings"
+)
+
+// Add the Content-Security-Policy nonce to every <script> and <style>
+// element and stylesheet <link>, so that a policy like
+// "script-src 'nonce-...'" allows them. Use a new random nonce for each
+// response, such as the one from webutil.CspNonce().
+//
+// A nonce can't be put on a style attribute, such as the ones Styles()
+// makes, so a "style-src" with a nonce blocks them unless the policy also
+// has "style-src-attr 'unsafe-inline'", as webutil.StrictCspPolicy does.
+func WithNonce(nonce string) Option {
+	return func(h *HtmlResponse) {
+		h.nonce = nonce
+	}
+}
+
+// Return the attributes with the response's nonce if the element needs one.
+// A nonce already in the attributes, such as one from a stored tree, is
+// replaced.
+func (h *HtmlResponse) addNonce(tag string, attrs []attr) []attr {
+	if h.nonce == "" || !needsNonce(tag, attrs) {
+		return attrs
+	}
+
+	withNonce := make([]attr, 0, len(attrs)+1)
+	for _, a := range attrs {
+		if a.name != "nonce" {
+			withNonce = append(withNonce, a)
+		}
+	}
+
+	return append(withNonce, Nonce(h.nonce))
+}
+
+// Whether the element loads or runs code or styles that a policy can allow
+// by nonce.
+func needsNonce(tag string, attrs []attr) bool {
+	switch tag {
+	case "script", "style":
+		return true
+
+	case "link":
+		for _, a := range attrs {
+			if a.name == "rel" {
+				for _, rel := range htmlFields(a.value) {
+					if strings.EqualFold(rel, "stylesheet") {
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	return false
+}
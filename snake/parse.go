@@ -0,0 +1,159 @@
+package snake
+
+import (
+	"io"
+	"strings"
+)
+
+// Elements whose start tag closes an open <p>.
+var closesParagraph = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"details": true, "div": true, "dl": true, "fieldset": true,
+	"figcaption": true, "figure": true, "footer": true, "form": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hgroup": true, "hr": true, "main": true, "menu": true,
+	"nav": true, "ol": true, "p": true, "pre": true, "section": true,
+	"table": true, "ul": true,
+}
+
+// For elements whose start tag implies the end of an open element: the
+// elements it closes, and the elements that stop the search.
+var impliedEndTags = map[string]struct {
+	closes, stops map[string]bool
+}{
+	"li":     {setOf("li"), setOf("ul", "ol", "menu")},
+	"dt":     {setOf("dt", "dd"), setOf("dl")},
+	"dd":     {setOf("dt", "dd"), setOf("dl")},
+	"tr":     {setOf("tr", "td", "th"), setOf("table", "thead", "tbody", "tfoot")},
+	"td":     {setOf("td", "th"), setOf("tr", "table")},
+	"th":     {setOf("td", "th"), setOf("tr", "table")},
+	"option": {setOf("option"), setOf("select", "datalist", "optgroup")},
+	"tbody":  {setOf("thead", "tbody", "tr", "td", "th"), setOf("table")},
+	"tfoot":  {setOf("thead", "tbody", "tr", "td", "th"), setOf("table")},
+}
+
+func setOf(names ...string) map[string]bool {
+	set := make(map[string]bool)
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// Parse HTML into a tree like the ones built by NewTree(). The parser is
+// forgiving the way browsers are: void elements need no closing tag, end
+// tags implied by the HTML spec (</p>, </li>, </td>, etc.) are filled in,
+// and stray end tags are ignored. It doesn't add missing <html>, <head>, or
+// <body> elements. The contents of <script> and <style> become raw nodes and
+// comments are kept as raw nodes.
+func Parse(r io.Reader) (*Node, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &Node{Type: DocumentNode}
+	t := &treeBuilder{root: root, current: root}
+	z := newTokenizer(string(data))
+
+	for {
+		tok, ok := z.next()
+		if !ok {
+			break
+		}
+
+		switch tok.typ {
+		case textToken:
+			if t.current.Type == ElementNode && (t.current.Tag == "script" || t.current.Tag == "style") {
+				t.addText(RawNode, tok.text)
+			} else {
+				t.addText(TextNode, tok.text)
+			}
+
+		case commentToken:
+			t.add(&Node{Type: RawNode, Text: "<!--" + tok.text + "-->"})
+
+		case doctypeToken:
+			t.add(&Node{Type: DoctypeNode})
+
+		case startTagToken, selfClosingTagToken:
+			if inForeignContent(t.current) || tok.tag == "svg" || tok.tag == "math" {
+				tok.tag, tok.attrs = adjustForeignCase(tok.tag, tok.attrs)
+			}
+			if tok.tag == "p" || closesParagraph[tok.tag] {
+				t.closeImplied(setOf("p"), closesParagraph)
+			}
+			if implied, ok := impliedEndTags[tok.tag]; ok {
+				t.closeImplied(implied.closes, implied.stops)
+			}
+
+			switch {
+			case voidElements[tok.tag]:
+				t.addElement(tok.tag, tok.attrs, false, false)
+			case tok.typ == selfClosingTagToken:
+				t.addElement(tok.tag, tok.attrs, true, false)
+			default:
+				t.addElement(tok.tag, tok.attrs, false, true)
+			}
+
+		case endTagToken:
+			t.closeElement(tok.tag)
+		}
+	}
+
+	return root, nil
+}
+
+// Close the innermost open element if it's one of closes, searching outward
+// but not past an element in stops.
+func (t *treeBuilder) closeImplied(closes, stops map[string]bool) {
+	for n := t.current; n != nil && n.Type == ElementNode; n = n.Parent {
+		if closes[n.Tag] {
+			t.current = n.Parent
+			return
+		}
+		if stops[n.Tag] {
+			return
+		}
+	}
+}
+
+// Mixed-case names of SVG elements and attributes, by their lowercase names.
+var foreignCaseNames = makeForeignCaseNames()
+
+func makeForeignCaseNames() map[string]string {
+	names := make(map[string]string)
+	for name := range foreignElementInfos {
+		names[strings.ToLower(name)] = name
+	}
+	for name := range attrInfos {
+		names[strings.ToLower(name)] = name
+	}
+
+	return names
+}
+
+// Return whether n is inside an <svg> or <math> element.
+func inForeignContent(n *Node) bool {
+	for ; n != nil && n.Type == ElementNode; n = n.Parent {
+		if n.Tag == "svg" || n.Tag == "math" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Restore the case of SVG names like "viewBox", which the tokenizer lowercases.
+func adjustForeignCase(tag string, attrs []attr) (string, []attr) {
+	if name, ok := foreignCaseNames[tag]; ok {
+		tag = name
+	}
+	for i := range attrs {
+		if name, ok := foreignCaseNames[attrs[i].name]; ok {
+			attrs[i].name = name
+		}
+	}
+
+	return tag, attrs
+}
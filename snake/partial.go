@@ -0,0 +1,85 @@
+package snake
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Query parameter that FragmentFromRequest() reads for requests that don't
+// come from htmx, such as "/orders?fragment=order-list".
+const FragmentParam = "fragment"
+
+// Mark the element for an out-of-band swap. When rendering a fragment, it's
+// sent along with the fragment, and htmx puts it in place of the element
+// with the same id, wherever it is on the page:
+//
+//	h.Span(Id("cart-count"), OutOfBand()).Content(count)
+func OutOfBand() attr {
+	return Attr("hx-swap-oob", "true")
+}
+
+// Return the id of the element whose contents the request asks for: the
+// HX-Target header of an htmx request, or the "fragment" query parameter.
+// Returns "" for a full page load.
+func FragmentFromRequest(r *http.Request) string {
+	if r.Header.Get("HX-Request") == "true" {
+		if target := r.Header.Get("HX-Target"); target != "" {
+			return target
+		}
+	}
+
+	return r.URL.Query().Get(FragmentParam)
+}
+
+// Render the whole component but write only the contents of the element with
+// the id, followed by any elements marked with OutOfBand() outside it. The
+// element's own tags aren't written, which suits htmx's default innerHTML
+// swap.
+func RenderFragment(w io.Writer, c Component, id string, options ...Option) error {
+	h := NewTree(options...)
+	h.Render(c)
+	err := h.Close()
+	if err != nil {
+		return err
+	}
+
+	root := h.Tree()
+	target := root.FindById(id)
+	if target == nil {
+		return fmt.Errorf("no element with id %q", id)
+	}
+
+	fragment := &Node{Type: DocumentNode}
+	for _, child := range append([]*Node(nil), target.Children...) {
+		fragment.AppendChild(child)
+	}
+	target.Remove()
+
+	// Out-of-band elements inside another one go along with it.
+	root.Walk(func(n *Node) bool {
+		if _, ok := n.Attr("hx-swap-oob"); ok && n.Type == ElementNode {
+			fragment.AppendChild(n)
+			return false
+		}
+		return true
+	})
+
+	return fragment.Serialize(w, options...)
+}
+
+// Render the whole page, or only the fragment the request asks for (see
+// FragmentFromRequest()), so that one render function serves both full page
+// loads and in-place updates.
+func RenderPage(w http.ResponseWriter, r *http.Request, c Component, options ...Option) error {
+	// The response depends on these headers, so caches must keep them apart.
+	w.Header().Add("Vary", "HX-Request, HX-Target")
+
+	if id := FragmentFromRequest(r); id != "" {
+		return RenderFragment(w, c, id, options...)
+	}
+
+	h := New(w, options...)
+	h.Render(c)
+	return h.Close()
+}
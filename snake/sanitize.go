@@ -0,0 +1,248 @@
+package snake
+
+import (
+	"strings"
+)
+
+// Elements whose contents are dropped along with their tags, since their
+// text isn't meant to be shown.
+var droppedContentElements = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"template": true,
+	"iframe":   true,
+	"object":   true,
+	"embed":    true,
+	"noscript": true,
+	"textarea": true,
+	"title":    true,
+	"head":     true,
+	"svg":      true,
+	"math":     true,
+}
+
+// Elements that are never allowed, whatever the policy says.
+var forbiddenElements = map[string]bool{
+	"script": true,
+	"style":  true,
+	"base":   true,
+	"meta":   true,
+	"link":   true,
+}
+
+// An allowlist of elements, attributes, and URL schemes for sanitizing
+// untrusted markup. Anything not allowed is dropped; the text inside a
+// dropped element is kept unless the element's contents aren't normally
+// visible (script, style, etc.).
+type Policy struct {
+	// Allowed elements, mapped to the attributes allowed on them.
+	elements map[string]map[string]bool
+
+	// Attributes allowed on every allowed element.
+	globalAttrs map[string]bool
+
+	// Schemes allowed in URL attributes. Relative URLs are always allowed.
+	schemes map[string]bool
+
+	// If not empty, "rel" attribute forced onto every link.
+	linkRel string
+}
+
+// Make a policy that allows nothing but text.
+func NewPolicy() *Policy {
+	return &Policy{
+		elements:    make(map[string]map[string]bool),
+		globalAttrs: make(map[string]bool),
+		schemes:     make(map[string]bool),
+	}
+}
+
+// Allow the elements, with no attributes beyond the global ones.
+func (p *Policy) AllowElements(tags ...string) *Policy {
+	for _, tag := range tags {
+		if !forbiddenElements[tag] && p.elements[tag] == nil {
+			p.elements[tag] = make(map[string]bool)
+		}
+	}
+
+	return p
+}
+
+// Allow the attributes on the element, allowing the element too. Use "*"
+// for attributes allowed on every allowed element. Event handler attributes
+// are never allowed.
+func (p *Policy) AllowAttrs(tag string, names ...string) *Policy {
+	attrs := p.globalAttrs
+	if tag != "*" {
+		p.AllowElements(tag)
+		attrs = p.elements[tag]
+		if attrs == nil {
+			// Forbidden element.
+			return p
+		}
+	}
+
+	for _, name := range names {
+		// Unknown attributes named like handlers may be new ones.
+		if _, known := attrInfos[name]; !eventHandlerAttrs[name] && (known || !strings.HasPrefix(name, "on")) {
+			attrs[name] = true
+		}
+	}
+
+	return p
+}
+
+// Allow URLs with these schemes, such as "https" or "mailto".
+func (p *Policy) AllowUrlSchemes(schemes ...string) *Policy {
+	for _, scheme := range schemes {
+		p.schemes[strings.ToLower(scheme)] = true
+	}
+
+	return p
+}
+
+// Force a "rel" attribute onto every <a>, such as "nofollow noopener".
+func (p *Policy) RequireLinkRel(rel string) *Policy {
+	p.linkRel = rel
+	return p
+}
+
+// A policy for basic formatting: paragraphs, line breaks, emphasis, lists,
+// quotes, code, and headings, with no attributes.
+func BasicFormattingPolicy() *Policy {
+	return NewPolicy().AllowElements(
+		"p", "br", "b", "strong", "i", "em", "u", "s", "del", "ins", "sub", "sup",
+		"small", "mark", "ul", "ol", "li", "blockquote", "pre", "code", "hr",
+		"h1", "h2", "h3", "h4", "h5", "h6")
+}
+
+// A policy for basic formatting plus links and images with http, https, and
+// mailto URLs. Links get rel="nofollow noopener".
+func LinksAndImagesPolicy() *Policy {
+	return BasicFormattingPolicy().
+		AllowAttrs("a", "href", "title").
+		AllowAttrs("img", "src", "alt", "title", "width", "height").
+		AllowUrlSchemes("http", "https", "mailto").
+		RequireLinkRel("nofollow noopener")
+}
+
+// Return whether the URL is relative or has an allowed scheme.
+func (p *Policy) allowsUrl(url string) bool {
+	url = strings.TrimSpace(url)
+
+	i := strings.IndexAny(url, ":/?#")
+	if i < 0 || url[i] != ':' {
+		return true
+	}
+
+	return p.schemes[strings.ToLower(url[:i])]
+}
+
+// Return the allowed attributes of an allowed element.
+func (p *Policy) filterAttrs(tag string, attrs []attr) []attr {
+	var allowed []attr
+
+	for _, a := range attrs {
+		if !p.globalAttrs[a.name] && !p.elements[tag][a.name] {
+			continue
+		}
+		if urlAttrs[a.name] {
+			if !p.allowsUrl(a.value) {
+				continue
+			}
+			// Checked against the policy's schemes rather than the default ones.
+			a = Trust(a)
+		}
+		if tag == "a" && a.name == "rel" && p.linkRel != "" {
+			continue
+		}
+		allowed = append(allowed, a)
+	}
+
+	if tag == "a" && p.linkRel != "" {
+		allowed = append(allowed, Rel(p.linkRel))
+	}
+
+	return allowed
+}
+
+// Parse the untrusted markup and write the parts that the policy allows.
+// The output is always well-formed: unclosed elements are closed and stray
+// closing tags are dropped.
+func (h *HtmlResponse) Sanitized(untrusted string, p *Policy) *HtmlResponse {
+	z := newTokenizer(untrusted)
+
+	// Elements we've opened, innermost last.
+	var open []string
+
+	// Element whose contents we're skipping, and how deeply it's nested.
+	skipTag := ""
+	skipDepth := 0
+
+	for {
+		t, ok := z.next()
+		if !ok {
+			break
+		}
+
+		if skipTag != "" {
+			if t.tag == skipTag {
+				switch t.typ {
+				case startTagToken:
+					skipDepth++
+				case endTagToken:
+					skipDepth--
+					if skipDepth == 0 {
+						skipTag = ""
+					}
+				}
+			}
+			continue
+		}
+
+		switch t.typ {
+		case textToken:
+			h.Text(t.text)
+
+		case startTagToken, selfClosingTagToken:
+			if p.elements[t.tag] == nil {
+				if droppedContentElements[t.tag] && t.typ == startTagToken && !voidElements[t.tag] {
+					skipTag = t.tag
+					skipDepth = 1
+				}
+				continue
+			}
+
+			attrs := p.filterAttrs(t.tag, t.attrs)
+			if voidElements[t.tag] {
+				h.singleTag(t.tag, attrs)
+			} else {
+				h.openTag(t.tag, attrs)
+				open = append(open, t.tag)
+				if t.typ == selfClosingTagToken {
+					h.closeTag(t.tag)
+					open = open[:len(open)-1]
+				}
+			}
+
+		case endTagToken:
+			// Close up to the matching element, if we opened one.
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == t.tag {
+					for len(open) > i {
+						h.closeTag(open[len(open)-1])
+						open = open[:len(open)-1]
+					}
+					break
+				}
+			}
+		}
+	}
+
+	for len(open) > 0 {
+		h.closeTag(open[len(open)-1])
+		open = open[:len(open)-1]
+	}
+
+	return h
+}
@@ -0,0 +1,558 @@
+package snake
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A compiled CSS selector for finding nodes in a tree. Supported are type,
+// universal, id, class, and attribute selectors (=, ~=, |=, ^=, $=, *=), the
+// descendant, child (>), next-sibling (+), and subsequent-sibling (~)
+// combinators, selector lists, and the pseudo-classes :first-child,
+// :last-child, :only-child, :nth-child(), :nth-last-child(), :empty, and
+// :not().
+type Selector struct {
+	alternatives []complexSelector
+}
+
+// Compound selectors joined by combinators, such as "ul.menu > li a".
+type complexSelector struct {
+	compounds []compoundSelector
+
+	// Combinator between each compound and the next: ' ', '>', '+', or '~'.
+	combinators []byte
+}
+
+// Conditions that must all hold for one element, such as "a.external[href]".
+type compoundSelector struct {
+	// Empty for any tag.
+	tag        string
+	conditions []func(n *Node) bool
+
+	// CSS specificity: ids*10000 + classes, attributes, and pseudo-classes*100
+	// + tags.
+	specificity int
+}
+
+// Parse a CSS selector.
+func CompileSelector(s string) (*Selector, error) {
+	p := &selectorParser{s: s}
+
+	selector := &Selector{}
+	for {
+		c, err := p.complex()
+		if err != nil {
+			return nil, fmt.Errorf("bad selector %q: %s", s, err)
+		}
+		selector.alternatives = append(selector.alternatives, c)
+
+		p.skipSpace()
+		if p.done() {
+			return selector, nil
+		}
+		if p.peek() != ',' {
+			return nil, fmt.Errorf("bad selector %q: unexpected %q", s, p.peek())
+		}
+		p.pos++
+	}
+}
+
+// Parse a CSS selector, panicking if it's not valid. For selectors in
+// variables and tests.
+func MustCompileSelector(s string) *Selector {
+	selector, err := CompileSelector(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return selector
+}
+
+// Return whether the node is an element matched by the selector.
+func (s *Selector) Matches(n *Node) bool {
+	if n.Type != ElementNode {
+		return false
+	}
+
+	for _, c := range s.alternatives {
+		if c.matches(n, len(c.compounds)-1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Return the highest specificity of the alternatives that match the node,
+// and whether any do.
+func (s *Selector) specificity(n *Node) (int, bool) {
+	best, matched := 0, false
+
+	if n.Type == ElementNode {
+		for _, c := range s.alternatives {
+			if c.matches(n, len(c.compounds)-1) {
+				specificity := 0
+				for _, compound := range c.compounds {
+					specificity += compound.specificity
+				}
+				if !matched || specificity > best {
+					best, matched = specificity, true
+				}
+			}
+		}
+	}
+
+	return best, matched
+}
+
+// Return the elements under n that match the selector, in document order.
+// Like querySelectorAll() in the browser, n itself is never returned.
+func (n *Node) Query(selector string) ([]*Node, error) {
+	s, err := CompileSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []*Node
+	for _, c := range n.Children {
+		found = append(found, c.FindAll(s.Matches)...)
+	}
+
+	return found, nil
+}
+
+// Return the first element under n that matches the selector, or nil.
+func (n *Node) QueryFirst(selector string) (*Node, error) {
+	found, err := n.Query(selector)
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+
+	return found[0], nil
+}
+
+// Return whether n matches the complex selector's compounds up to index i.
+func (c *complexSelector) matches(n *Node, i int) bool {
+	if !c.compounds[i].matches(n) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+
+	switch c.combinators[i-1] {
+	case '>':
+		parent := parentElement(n)
+		return parent != nil && c.matches(parent, i-1)
+	case ' ':
+		for a := parentElement(n); a != nil; a = parentElement(a) {
+			if c.matches(a, i-1) {
+				return true
+			}
+		}
+	case '+':
+		siblings, index := elementSiblings(n)
+		return index > 0 && c.matches(siblings[index-1], i-1)
+	case '~':
+		siblings, index := elementSiblings(n)
+		for j := index - 1; j >= 0; j-- {
+			if c.matches(siblings[j], i-1) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (c *compoundSelector) matches(n *Node) bool {
+	if n.Type != ElementNode || (c.tag != "" && !strings.EqualFold(c.tag, n.Tag)) {
+		return false
+	}
+
+	for _, condition := range c.conditions {
+		if !condition(n) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Return the parent of n if it's an element.
+func parentElement(n *Node) *Node {
+	if n.Parent == nil || n.Parent.Type != ElementNode {
+		return nil
+	}
+
+	return n.Parent
+}
+
+// Return the elements among n's siblings (including n) and n's index in them.
+func elementSiblings(n *Node) ([]*Node, int) {
+	if n.Parent == nil {
+		return []*Node{n}, 0
+	}
+
+	var siblings []*Node
+	index := 0
+	for _, c := range n.Parent.Children {
+		if c == n {
+			index = len(siblings)
+		}
+		if c.Type == ElementNode {
+			siblings = append(siblings, c)
+		}
+	}
+
+	return siblings, index
+}
+
+type selectorParser struct {
+	s   string
+	pos int
+}
+
+func (p *selectorParser) done() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *selectorParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+
+	return p.s[p.pos]
+}
+
+// Skip whitespace, returning whether there was any.
+func (p *selectorParser) skipSpace() bool {
+	start := p.pos
+	for !p.done() && isSpace(p.peek()) {
+		p.pos++
+	}
+
+	return p.pos > start
+}
+
+// Parse a name such as a tag, class, or attribute.
+func (p *selectorParser) ident() (string, error) {
+	start := p.pos
+	for !p.done() {
+		ch := p.peek()
+		if !isAsciiLetter(ch) && !(ch >= '0' && ch <= '9') && ch != '-' && ch != '_' && ch < 0x80 {
+			break
+		}
+		p.pos++
+	}
+
+	if p.pos == start {
+		if p.done() {
+			return "", fmt.Errorf("unexpected end")
+		}
+		return "", fmt.Errorf("unexpected %q", p.peek())
+	}
+
+	return p.s[start:p.pos], nil
+}
+
+// Parse a complex selector, stopping at a comma or the end.
+func (p *selectorParser) complex() (complexSelector, error) {
+	var c complexSelector
+
+	p.skipSpace()
+	for {
+		compound, err := p.compound()
+		if err != nil {
+			return c, err
+		}
+		c.compounds = append(c.compounds, compound)
+
+		hadSpace := p.skipSpace()
+		combinator := byte(' ')
+		switch p.peek() {
+		case '>', '+', '~':
+			combinator = p.peek()
+			p.pos++
+			p.skipSpace()
+		case ',', ')', 0:
+			return c, nil
+		default:
+			if !hadSpace {
+				return c, fmt.Errorf("unexpected %q", p.peek())
+			}
+		}
+		c.combinators = append(c.combinators, combinator)
+	}
+}
+
+// Parse a compound selector.
+func (p *selectorParser) compound() (compoundSelector, error) {
+	var c compoundSelector
+
+	// Whether there's a type or universal selector.
+	hasType := true
+	switch {
+	case p.peek() == '*':
+		p.pos++
+	case isAsciiLetter(p.peek()):
+		c.tag, _ = p.ident()
+		c.specificity++
+	default:
+		hasType = false
+	}
+
+	for !p.done() {
+		var condition func(n *Node) bool
+		var err error
+
+		// Ids count for more than classes, attributes, and pseudo-classes.
+		specificity := 100
+
+		switch p.peek() {
+		case '#':
+			p.pos++
+			specificity = 10000
+			var id string
+			id, err = p.ident()
+			condition = func(n *Node) bool {
+				value, ok := n.Attr("id")
+				return ok && value == id
+			}
+		case '.':
+			p.pos++
+			var class string
+			class, err = p.ident()
+			condition = func(n *Node) bool {
+				return n.HasClass(class)
+			}
+		case '[':
+			p.pos++
+			condition, err = p.attribute()
+		case ':':
+			p.pos++
+			condition, specificity, err = p.pseudoClass()
+		default:
+			if !hasType && len(c.conditions) == 0 {
+				return c, fmt.Errorf("unexpected %q", p.peek())
+			}
+			return c, nil
+		}
+
+		if err != nil {
+			return c, err
+		}
+		c.conditions = append(c.conditions, condition)
+		c.specificity += specificity
+	}
+
+	if !hasType && len(c.conditions) == 0 {
+		return c, fmt.Errorf("unexpected end")
+	}
+
+	return c, nil
+}
+
+// Parse an attribute selector after its "[".
+func (p *selectorParser) attribute() (func(n *Node) bool, error) {
+	p.skipSpace()
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+
+	if p.peek() == ']' {
+		p.pos++
+		return func(n *Node) bool {
+			_, ok := n.Attr(name)
+			return ok
+		}, nil
+	}
+
+	op := ""
+	if p.peek() != '=' {
+		op = string(p.peek())
+		p.pos++
+	}
+	if p.peek() != '=' {
+		return nil, fmt.Errorf("bad attribute operator")
+	}
+	p.pos++
+	p.skipSpace()
+
+	var value string
+	if quote := p.peek(); quote == '"' || quote == '\'' {
+		end := strings.IndexByte(p.s[p.pos+1:], quote)
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed string")
+		}
+		value = p.s[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+	} else {
+		value, err = p.ident()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p.skipSpace()
+	if p.peek() != ']' {
+		return nil, fmt.Errorf("missing ]")
+	}
+	p.pos++
+
+	var test func(s string) bool
+	switch op {
+	case "":
+		test = func(s string) bool { return s == value }
+	case "~":
+		test = func(s string) bool {
+			for _, word := range strings.Fields(s) {
+				if word == value {
+					return true
+				}
+			}
+			return false
+		}
+	case "|":
+		test = func(s string) bool { return s == value || strings.HasPrefix(s, value+"-") }
+	case "^":
+		test = func(s string) bool { return value != "" && strings.HasPrefix(s, value) }
+	case "$":
+		test = func(s string) bool { return value != "" && strings.HasSuffix(s, value) }
+	case "*":
+		test = func(s string) bool { return value != "" && strings.Contains(s, value) }
+	default:
+		return nil, fmt.Errorf("bad attribute operator %q", op)
+	}
+
+	return func(n *Node) bool {
+		s, ok := n.Attr(name)
+		return ok && test(s)
+	}, nil
+}
+
+// Parse a pseudo-class after its ":".
+func (p *selectorParser) pseudoClass() (func(n *Node) bool, int, error) {
+	name, err := p.ident()
+	if err != nil {
+		return nil, 0, err
+	}
+	name = strings.ToLower(name)
+
+	switch name {
+	case "first-child":
+		return func(n *Node) bool {
+			_, index := elementSiblings(n)
+			return index == 0
+		}, 100, nil
+	case "last-child":
+		return func(n *Node) bool {
+			siblings, index := elementSiblings(n)
+			return index == len(siblings)-1
+		}, 100, nil
+	case "only-child":
+		return func(n *Node) bool {
+			siblings, _ := elementSiblings(n)
+			return len(siblings) == 1
+		}, 100, nil
+	case "empty":
+		return func(n *Node) bool {
+			for _, c := range n.Children {
+				if c.Type == ElementNode || c.Text != "" {
+					return false
+				}
+			}
+			return true
+		}, 100, nil
+	}
+
+	// The rest take an argument.
+	if p.peek() != '(' {
+		return nil, 0, fmt.Errorf("unknown pseudo-class :%s", name)
+	}
+	p.pos++
+	end := strings.IndexByte(p.s[p.pos:], ')')
+	if end < 0 {
+		return nil, 0, fmt.Errorf("missing )")
+	}
+
+	switch name {
+	case "nth-child", "nth-last-child":
+		a, b, err := parseNth(p.s[p.pos : p.pos+end])
+		if err != nil {
+			return nil, 0, err
+		}
+		p.pos += end + 1
+
+		fromEnd := name == "nth-last-child"
+		return func(n *Node) bool {
+			siblings, index := elementSiblings(n)
+			k := index + 1
+			if fromEnd {
+				k = len(siblings) - index
+			}
+			if a == 0 {
+				return k == b
+			}
+			return (k-b)/a >= 0 && (k-b)%a == 0
+		}, 100, nil
+
+	case "not":
+		p.skipSpace()
+		c, err := p.compound()
+		if err != nil {
+			return nil, 0, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, 0, fmt.Errorf("missing )")
+		}
+		p.pos++
+
+		// :not() counts as its argument.
+		return func(n *Node) bool {
+			return !c.matches(n)
+		}, c.specificity, nil
+	}
+
+	return nil, 0, fmt.Errorf("unknown pseudo-class :%s", name)
+}
+
+// Parse the "an+b" argument of :nth-child().
+func parseNth(s string) (a, b int, err error) {
+	s = strings.ToLower(strings.Join(strings.Fields(s), ""))
+
+	switch s {
+	case "odd":
+		return 2, 1, nil
+	case "even":
+		return 2, 0, nil
+	}
+
+	aPart, bPart, hasN := strings.Cut(s, "n")
+	if !hasN {
+		b, err = strconv.Atoi(s)
+		return 0, b, err
+	}
+
+	switch aPart {
+	case "", "+":
+		a = 1
+	case "-":
+		a = -1
+	default:
+		a, err = strconv.Atoi(aPart)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if bPart != "" {
+		b, err = strconv.Atoi(strings.TrimPrefix(bPart, "+"))
+	}
+
+	return a, b, err
+}
@@ -0,0 +1,105 @@
+package snaketest
+
+import (
+	"github.com/lkesteloot/goutil/snake"
+	"strings"
+)
+
+// Return a line diff of the two texts, with removed lines starting with "-",
+// added lines with "+", and unchanged lines with a space. Runs of more than
+// three unchanged lines are elided.
+func Diff(a, b string) string {
+	x := strings.Split(a, "\n")
+	y := strings.Split(b, "\n")
+
+	// Longest common subsequence lengths of the suffixes.
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			lines = append(lines, " "+x[i])
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "-"+x[i])
+			i++
+		default:
+			lines = append(lines, "+"+y[j])
+			j++
+		}
+	}
+
+	return strings.Join(elideUnchanged(lines), "\n")
+}
+
+// Replace long runs of unchanged lines with "...".
+func elideUnchanged(lines []string) []string {
+	const context = 3
+
+	var kept []string
+	for i := 0; i < len(lines); {
+		if lines[i][0] != ' ' {
+			kept = append(kept, lines[i])
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(lines) && lines[end][0] == ' ' {
+			end++
+		}
+		if end-i > 2*context {
+			kept = append(kept, lines[i:i+context]...)
+			kept = append(kept, "...")
+			kept = append(kept, lines[end-context:end]...)
+		} else {
+			kept = append(kept, lines[i:end]...)
+		}
+		i = end
+	}
+
+	return kept
+}
+
+// Elements whose whitespace matters.
+var preserveSpace = map[string]bool{
+	"pre":      true,
+	"textarea": true,
+	"script":   true,
+	"style":    true,
+}
+
+// Return a copy of the tree without whitespace-only text, except inside
+// elements where whitespace matters.
+func normalizeSpace(n *snake.Node) *snake.Node {
+	h := snake.NewTree(snake.WithValidation(snake.ValidateOff))
+	n.Render(h)
+	root := h.Tree()
+
+	root.Walk(func(c *snake.Node) bool {
+		if c.Type == snake.ElementNode && preserveSpace[c.Tag] {
+			return false
+		}
+		if c.Type == snake.TextNode && strings.TrimSpace(c.Text) == "" {
+			c.Remove()
+		}
+		return true
+	})
+
+	return root
+}
@@ -0,0 +1,199 @@
+// Helpers for testing code that renders with snake. Parse the output into a
+// Document, then check it with CSS selectors:
+//
+//	d := snaketest.Record(t, handler, httptest.NewRequest("GET", "/", nil))
+//	d.AssertCount("table.results tr", 3)
+//	d.AssertText("#title", "Orders")
+//	d.AssertAttr("form", "action", "/orders")
+//	d.AssertAccessible()
+//	d.AssertGolden("orders")
+//
+// Golden files live in testdata/ and are rewritten when the tests are run
+// with the -snaketest.update flag.
+package snaketest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/lkesteloot/goutil/snake"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("snaketest.update", false, "update golden files")
+
+// Parsed output that assertions are made against. Assertions report
+// failures with t.Errorf(), so a test can make many of them.
+type Document struct {
+	t    testing.TB
+	root *snake.Node
+}
+
+// Parse the markup.
+func Parse(t testing.TB, html string) *Document {
+	t.Helper()
+
+	root, err := snake.Parse(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("can't parse HTML: %s", err)
+	}
+
+	return &Document{t: t, root: root}
+}
+
+// Render the component to a streaming response and parse the output.
+func Render(t testing.TB, c snake.Component, options ...snake.Option) *Document {
+	t.Helper()
+
+	var buf bytes.Buffer
+	h := snake.New(&buf, options...)
+	h.Render(c)
+	if err := h.Close(); err != nil {
+		t.Fatalf("can't render: %s", err)
+	}
+
+	return Parse(t, buf.String())
+}
+
+// Call the handler with the request and parse its response. Fails the test
+// if the status isn't 200.
+func Record(t testing.TB, handler http.Handler, r *http.Request) *Document {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d for %s", w.Code, r.URL)
+	}
+
+	return Parse(t, w.Body.String())
+}
+
+// Return the root of the parsed tree.
+func (d *Document) Root() *snake.Node {
+	return d.root
+}
+
+// Return the elements matching the selector.
+func (d *Document) Find(selector string) []*snake.Node {
+	d.t.Helper()
+
+	found, err := d.root.Query(selector)
+	if err != nil {
+		d.t.Fatal(err)
+	}
+
+	return found
+}
+
+// Check the number of elements matching the selector.
+func (d *Document) AssertCount(selector string, expected int) {
+	d.t.Helper()
+
+	if found := d.Find(selector); len(found) != expected {
+		d.t.Errorf("%q: got %d elements, expected %d", selector, len(found), expected)
+	}
+}
+
+// Return the first element matching the selector, failing if there isn't one.
+func (d *Document) first(selector string) *snake.Node {
+	d.t.Helper()
+
+	found := d.Find(selector)
+	if len(found) == 0 {
+		d.t.Errorf("%q: no matching element", selector)
+		return nil
+	}
+
+	return found[0]
+}
+
+// Check the text of the first element matching the selector. Runs of
+// whitespace are collapsed and leading and trailing whitespace is ignored.
+func (d *Document) AssertText(selector, expected string) {
+	d.t.Helper()
+
+	if n := d.first(selector); n != nil {
+		text := strings.Join(strings.Fields(n.TextContent()), " ")
+		if text != expected {
+			d.t.Errorf("%q: got text %q, expected %q", selector, text, expected)
+		}
+	}
+}
+
+// Check an attribute of the first element matching the selector.
+func (d *Document) AssertAttr(selector, name, expected string) {
+	d.t.Helper()
+
+	if n := d.first(selector); n != nil {
+		value, ok := n.Attr(name)
+		switch {
+		case !ok:
+			d.t.Errorf("%q: no %s attribute, expected %q", selector, name, expected)
+		case value != expected:
+			d.t.Errorf("%q: got %s %q, expected %q", selector, name, value, expected)
+		}
+	}
+}
+
+// Check the document for accessibility problems with snake.Lint().
+func (d *Document) AssertAccessible() {
+	d.t.Helper()
+
+	for _, e := range snake.Lint(d.root) {
+		d.t.Error(e)
+	}
+}
+
+// Return the document pretty-printed, with the whitespace between tags
+// replaced by the printer's, so that golden files diff well. Attribute
+// values are written as they are, without sanitizing.
+func (d *Document) String() string {
+	var buf bytes.Buffer
+	err := normalizeSpace(d.root).TrustAttrs().Serialize(&buf, snake.WithOutputMode(snake.OutputPretty),
+		snake.WithValidation(snake.ValidateOff))
+	if err != nil {
+		return fmt.Sprintf("<!-- %s -->", err)
+	}
+
+	return buf.String()
+}
+
+// Compare the pretty-printed document to testdata/name.golden, or write it
+// there with -snaketest.update.
+func (d *Document) AssertGolden(name string) {
+	d.t.Helper()
+	AssertGolden(d.t, name, d.String())
+}
+
+// Compare the text to testdata/name.golden, or write it there with
+// -snaketest.update. A mismatch is reported as a line diff.
+func AssertGolden(t testing.TB, name, actual string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err == nil {
+			err = os.WriteFile(path, []byte(actual), 0644)
+		}
+		if err != nil {
+			t.Fatalf("can't update golden file: %s", err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("can't read golden file (run with -snaketest.update to create it): %s", err)
+	}
+
+	if string(expected) != actual {
+		t.Errorf("output differs from %s (- expected, + actual):\n%s", path, Diff(string(expected), actual))
+	}
+}
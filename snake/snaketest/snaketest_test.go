@@ -0,0 +1,59 @@
+package snaketest
+
+import (
+	"github.com/lkesteloot/goutil/snake"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ordersPage(w http.ResponseWriter, r *http.Request) {
+	h := snake.New(w)
+	h.Doctype().Html().Head().Title().Content("Orders").Head_().Body()
+	h.H1(snake.Id("title")).Content("Orders").
+		Form(snake.Action("/orders")).Form_()
+	h.Table(snake.Class("results"))
+	for _, customer := range []string{"Alice", "Bob"} {
+		h.Tr().Td().Content(customer).Tr_()
+	}
+	h.Table_().Body_().Html_()
+	h.Close()
+}
+
+func TestAssertions(t *testing.T) {
+	d := Record(t, http.HandlerFunc(ordersPage), httptest.NewRequest("GET", "/", nil))
+	d.AssertCount("table.results tr", 2)
+	d.AssertCount("tr:first-child > td", 1)
+	d.AssertText("#title", "Orders")
+	d.AssertText("tr + tr td", "Bob")
+	d.AssertAttr("form", "action", "/orders")
+	d.AssertGolden("orders")
+}
+
+func TestDiff(t *testing.T) {
+	diff := Diff("a\nb\nc\nd\ne\nf\ng\nh\ni", "a\nB\nc\nd\ne\nf\ng\nh")
+	expected := strings.Join([]string{" a", "-b", "+B", " c", " d", " e", " f", " g", " h", "-i"}, "\n")
+	if diff != expected {
+		t.Errorf("got\n%s\nexpected\n%s", diff, expected)
+	}
+
+	diff = Diff("1\n2\n3\n4\n5\n6\n7\n8\nx", "1\n2\n3\n4\n5\n6\n7\n8\ny")
+	expected = strings.Join([]string{" 1", " 2", " 3", "...", " 6", " 7", " 8", "-x", "+y"}, "\n")
+	if diff != expected {
+		t.Errorf("got\n%s\nexpected\n%s", diff, expected)
+	}
+}
+
+func TestStringKeepsTrustedAttrs(t *testing.T) {
+	d := Render(t, snake.ComponentFunc(func(h *snake.HtmlResponse) {
+		h.A(snake.Trust(snake.Href("javascript:void(0)")),
+			snake.Trust(snake.OnClick("go()")),
+			snake.Trust(snake.Style("background: url(/a.png)"))).Content("Go")
+	}))
+
+	expected := `<a href="javascript:void(0)" onclick="go()" style="background: url(/a.png)">Go</a>`
+	if s := strings.TrimSpace(d.String()); s != expected {
+		t.Errorf("got %s, expected %s", s, expected)
+	}
+}
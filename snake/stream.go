@@ -0,0 +1,46 @@
+package snake
+
+// Flush the response to the client once the <head> has been written, so
+// the browser can start fetching stylesheets and scripts while the rest of
+// the page is generated. In a layout, stylesheets linked after that are
+// written where they're linked, since the <head> has already been sent.
+func WithFlushAfterHead() Option {
+	return func(h *HtmlResponse) {
+		h.flushAfterHead = true
+	}
+}
+
+// Flush the response to the client after every n table rows, so long
+// reports show up progressively.
+func WithFlushEveryRows(n int) Option {
+	return func(h *HtmlResponse) {
+		h.flushEveryRows = n
+	}
+}
+
+// Called after a closing tag is written, to flush at automatic flush points.
+func (h *HtmlResponse) flushAfterClose(tag string) {
+	switch tag {
+	case "head":
+		if h.flushAfterHead {
+			h.autoFlush()
+		}
+	case "tr":
+		if h.flushEveryRows > 0 {
+			h.rowCount++
+			if h.rowCount%h.flushEveryRows == 0 {
+				h.autoFlush()
+			}
+		}
+	}
+}
+
+// Flush at an automatic flush point. A layout buffers its page, so it's
+// asked to write out what it can first.
+func (h *HtmlResponse) autoFlush() {
+	if h.layout != nil {
+		h.layout.flush(h)
+	} else {
+		h.Flush()
+	}
+}
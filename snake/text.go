@@ -0,0 +1,355 @@
+package snake
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Elements set off from their surroundings by blank lines in plain text.
+var textParagraphElements = map[string]bool{
+	"address":    true,
+	"blockquote": true,
+	"details":    true,
+	"dl":         true,
+	"figure":     true,
+	"h1":         true,
+	"h2":         true,
+	"h3":         true,
+	"h4":         true,
+	"h5":         true,
+	"h6":         true,
+	"hr":         true,
+	"ol":         true,
+	"p":          true,
+	"pre":        true,
+	"table":      true,
+	"ul":         true,
+}
+
+// Elements left out of plain text.
+var textSkippedElements = map[string]bool{
+	"head":     true,
+	"input":    true,
+	"math":     true,
+	"noscript": true,
+	"script":   true,
+	"select":   true,
+	"style":    true,
+	"svg":      true,
+	"template": true,
+	"textarea": true,
+	"title":    true,
+}
+
+// Converts a tree to plain text.
+type textRenderer struct {
+	out strings.Builder
+
+	// Text of the line being built, and whether a space is due before the
+	// next word.
+	line  strings.Builder
+	space bool
+
+	// Written before each line, such as "  " in a list item or "> " in a
+	// quote, and the prefix for the next line only, such as a bullet.
+	prefix      string
+	firstPrefix string
+
+	// Whether anything has been written, and whether a blank line is due
+	// before the next line, with the prefix of the block that asked for it.
+	started     bool
+	blank       bool
+	blankPrefix string
+}
+
+// Render the node and everything under it as readable plain text:
+// paragraphs separated by blank lines, bulleted and numbered lists, tables
+// with aligned columns, and link URLs in brackets after the link text.
+func (n *Node) PlainText() string {
+	r := &textRenderer{}
+	r.render(n)
+	r.flushLine()
+
+	return r.out.String()
+}
+
+// Render the component as plain text with the same calls that render it as
+// HTML. See (*Node).PlainText().
+func RenderText(c Component, options ...Option) (string, error) {
+	h := NewTree(options...)
+	h.Render(c)
+	err := h.Close()
+
+	return h.Tree().PlainText(), err
+}
+
+// Add text, collapsing whitespace.
+func (r *textRenderer) text(s string) {
+	if s != "" && isSpace(s[0]) {
+		r.space = true
+	}
+
+	for i, word := range htmlFields(s) {
+		if (i > 0 || r.space) && r.line.Len() > 0 {
+			r.line.WriteByte(' ')
+		}
+		r.line.WriteString(word)
+		r.space = false
+	}
+
+	if s != "" && isSpace(s[len(s)-1]) {
+		r.space = true
+	}
+}
+
+// Write a line with the current prefix.
+func (r *textRenderer) writeLine(s string) {
+	if r.blank && r.started {
+		r.out.WriteString(strings.TrimRight(r.blankPrefix, " "))
+		r.out.WriteString("\n")
+	}
+
+	prefix := r.prefix
+	if r.firstPrefix != "" {
+		prefix = r.firstPrefix
+		r.firstPrefix = ""
+	}
+
+	r.out.WriteString(strings.TrimRight(prefix+s, " "))
+	r.out.WriteString("\n")
+	r.started = true
+	r.blank = false
+}
+
+// End the line being built, if there is one.
+func (r *textRenderer) flushLine() {
+	if r.line.Len() > 0 {
+		r.writeLine(r.line.String())
+		r.line.Reset()
+	}
+	r.space = false
+}
+
+// End the line being built and ask for a blank line before the next one.
+func (r *textRenderer) paragraph() {
+	r.flushLine()
+	// Between blocks at different depths, the blank line belongs to the
+	// outer one.
+	if !r.blank || len(r.prefix) < len(r.blankPrefix) {
+		r.blankPrefix = r.prefix
+	}
+	r.blank = true
+}
+
+func (r *textRenderer) renderChildren(n *Node) {
+	for _, c := range n.Children {
+		r.render(c)
+	}
+}
+
+func (r *textRenderer) render(n *Node) {
+	switch n.Type {
+	case DocumentNode:
+		r.renderChildren(n)
+	case TextNode:
+		r.text(n.Text)
+	case RawNode:
+		// Markup written with RawText().
+		if doc, err := Parse(strings.NewReader(n.Text)); err == nil {
+			r.renderChildren(doc)
+		}
+	case ElementNode:
+		r.renderElement(n)
+	}
+}
+
+func (r *textRenderer) renderElement(n *Node) {
+	if textSkippedElements[n.Tag] {
+		return
+	}
+
+	inList := hasAncestor(n, "li")
+	switch {
+	case textParagraphElements[n.Tag] && !(inList && (n.Tag == "ul" || n.Tag == "ol")):
+		r.paragraph()
+		defer r.paragraph()
+	case blockElements[n.Tag] || n.Tag == "li" || n.Tag == "dt" || n.Tag == "dd":
+		r.flushLine()
+		defer r.flushLine()
+	}
+
+	switch n.Tag {
+	case "br":
+		if r.line.Len() == 0 {
+			r.writeLine("")
+		}
+		r.flushLine()
+
+	case "hr":
+		r.writeLine("----------")
+
+	case "h1", "h2":
+		r.renderChildren(n)
+		length := utf8.RuneCountInString(r.line.String())
+		r.flushLine()
+		underline := "="
+		if n.Tag == "h2" {
+			underline = "-"
+		}
+		r.writeLine(strings.Repeat(underline, length))
+
+	case "a":
+		r.renderChildren(n)
+		href, _ := n.Attr("href")
+		text := strings.TrimSpace(n.TextContent())
+		if href != "" && !strings.HasPrefix(href, "#") && href != text &&
+			strings.TrimPrefix(href, "mailto:") != text {
+
+			r.text(" [" + href + "]")
+		}
+
+	case "img":
+		if alt, _ := n.Attr("alt"); alt != "" {
+			r.text(alt)
+		}
+
+	case "ul", "ol":
+		r.renderList(n)
+
+	case "dd":
+		saved := r.prefix
+		r.prefix += "    "
+		r.renderChildren(n)
+		r.flushLine()
+		r.prefix = saved
+
+	case "blockquote":
+		saved := r.prefix
+		r.prefix += "> "
+		r.renderChildren(n)
+		r.flushLine()
+		r.prefix = saved
+
+	case "pre":
+		text := strings.TrimSuffix(strings.TrimPrefix(n.TextContent(), "\n"), "\n")
+		for _, line := range strings.Split(text, "\n") {
+			r.writeLine(line)
+		}
+
+	case "table":
+		r.renderTable(n)
+
+	default:
+		r.renderChildren(n)
+	}
+}
+
+// Write the items of a list with bullets or numbers.
+func (r *textRenderer) renderList(n *Node) {
+	saved := r.prefix
+	number := 1
+	if start, ok := n.Attr("start"); ok {
+		if i, err := strconv.Atoi(start); err == nil {
+			number = i
+		}
+	}
+
+	for _, item := range n.Children {
+		if item.Type != ElementNode || item.Tag != "li" {
+			r.render(item)
+			continue
+		}
+
+		marker := "* "
+		if n.Tag == "ol" {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+
+		r.flushLine()
+		r.firstPrefix = saved + marker
+		r.prefix = saved + strings.Repeat(" ", len(marker))
+		r.renderElement(item)
+		r.firstPrefix = ""
+		r.prefix = saved
+	}
+}
+
+// Return the table's own rows, leaving out those of tables inside it.
+func tableRows(table *Node) []*Node {
+	var rows []*Node
+
+	for _, c := range table.Children {
+		if c.Type != ElementNode {
+			continue
+		}
+		switch c.Tag {
+		case "tr":
+			rows = append(rows, c)
+		case "thead", "tbody", "tfoot":
+			for _, tr := range c.Children {
+				if tr.Type == ElementNode && tr.Tag == "tr" {
+					rows = append(rows, tr)
+				}
+			}
+		}
+	}
+
+	return rows
+}
+
+// Write the table with its columns aligned, and a line under a header row.
+func (r *textRenderer) renderTable(n *Node) {
+	var rows [][]string
+	var headerRows []bool
+	var widths []int
+
+	for _, tr := range tableRows(n) {
+		var cells []string
+		header := true
+		for _, cell := range tr.Children {
+			if cell.Type != ElementNode || (cell.Tag != "td" && cell.Tag != "th") {
+				continue
+			}
+			header = header && cell.Tag == "th"
+
+			// Blocks and line breaks inside the cell go on one line.
+			cr := &textRenderer{}
+			cr.renderChildren(cell)
+			cr.flushLine()
+			text := strings.Join(htmlFields(cr.out.String()), " ")
+			cells = append(cells, text)
+
+			i := len(cells) - 1
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if w := utf8.RuneCountInString(text); w > widths[i] {
+				widths[i] = w
+			}
+		}
+		rows = append(rows, cells)
+		headerRows = append(headerRows, header && len(cells) > 0)
+	}
+
+	for i, cells := range rows {
+		var line strings.Builder
+		for j, cell := range cells {
+			if j > 0 {
+				line.WriteString("  ")
+			}
+			line.WriteString(cell)
+			line.WriteString(strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell)))
+		}
+		r.writeLine(line.String())
+
+		if headerRows[i] {
+			var rule []string
+			for j := range cells {
+				rule = append(rule, strings.Repeat("-", widths[j]))
+			}
+			r.writeLine(strings.Join(rule, "  "))
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package snake
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kinds of token produced by the tokenizer.
+type tokenType int
+
+const (
+	textToken tokenType = iota
+	startTagToken
+	endTagToken
+	selfClosingTagToken
+	commentToken
+	doctypeToken
+)
+
+// One piece of parsed markup. Text and attribute values are unescaped.
+type token struct {
+	typ   tokenType
+	tag   string
+	attrs []attr
+	text  string
+}
+
+// Elements whose contents are raw text, ending only at their closing tag.
+var rawTextElements = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"textarea": true,
+	"title":    true,
+	"xmp":      true,
+	"iframe":   true,
+	"noembed":  true,
+	"noframes": true,
+}
+
+// A forgiving HTML tokenizer. It doesn't build a tree or fix up tag
+// nesting; it just splits markup into tags, text, and comments the way a
+// browser would.
+type tokenizer struct {
+	s   string
+	pos int
+
+	// Tag whose raw text contents we're in, or "".
+	rawTag string
+}
+
+func newTokenizer(s string) *tokenizer {
+	return &tokenizer{s: s}
+}
+
+// Return the next token, or false at the end of the input.
+func (z *tokenizer) next() (token, bool) {
+	if z.pos >= len(z.s) {
+		return token{}, false
+	}
+
+	if z.rawTag != "" {
+		return z.rawText(), true
+	}
+
+	rest := z.s[z.pos:]
+	if rest[0] == '<' && len(rest) > 1 {
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			return z.comment(), true
+		case rest[1] == '!' || rest[1] == '?':
+			return z.bogusComment(), true
+		case rest[1] == '/' && len(rest) > 2 && isAsciiLetter(rest[2]):
+			return z.tag(), true
+		case isAsciiLetter(rest[1]):
+			return z.tag(), true
+		}
+	}
+
+	// Text up to the next thing that looks like a tag.
+	end := len(z.s)
+	for i := z.pos + 1; i < len(z.s); i++ {
+		if z.s[i] == '<' {
+			end = i
+			break
+		}
+	}
+	text := z.s[z.pos:end]
+	z.pos = end
+
+	return token{typ: textToken, text: html.UnescapeString(text)}, true
+}
+
+// Read the contents of a raw text element.
+func (z *tokenizer) rawText() token {
+	rest := z.s[z.pos:]
+	end := rawTextEnd(rest, z.rawTag)
+	z.pos += end
+
+	text := rest[:end]
+	if z.rawTag == "textarea" || z.rawTag == "title" {
+		text = html.UnescapeString(text)
+	}
+	z.rawTag = ""
+
+	return token{typ: textToken, text: text}
+}
+
+// Return the index of the closing tag in s, or len(s). Tag names are ASCII,
+// so they're compared byte for byte rather than by lowercasing s, which can
+// change its length.
+func rawTextEnd(s, tag string) int {
+	for i := 0; i+2+len(tag) <= len(s); i++ {
+		if s[i] == '<' && s[i+1] == '/' && strings.EqualFold(s[i+2:i+2+len(tag)], tag) {
+			return i
+		}
+	}
+
+	return len(s)
+}
+
+// Read a <!-- comment -->.
+func (z *tokenizer) comment() token {
+	start := z.pos + 4
+	end := strings.Index(z.s[start:], "-->")
+	if end < 0 {
+		z.pos = len(z.s)
+		return token{typ: commentToken, text: z.s[start:]}
+	}
+
+	z.pos = start + end + 3
+	return token{typ: commentToken, text: z.s[start : start+end]}
+}
+
+// Read a <!DOCTYPE>, <![CDATA[...]]>, <?xml?> or similar, which browsers
+// treat as comments when they aren't doctypes.
+func (z *tokenizer) bogusComment() token {
+	start := z.pos + 2
+	end := strings.IndexByte(z.s[start:], '>')
+	var text string
+	if end < 0 {
+		text = z.s[start:]
+		z.pos = len(z.s)
+	} else {
+		text = z.s[start : start+end]
+		z.pos = start + end + 1
+	}
+
+	if strings.HasPrefix(strings.ToLower(text), "doctype") {
+		return token{typ: doctypeToken, text: strings.TrimSpace(text[len("doctype"):])}
+	}
+
+	return token{typ: commentToken, text: text}
+}
+
+// Read a start or end tag, including its attributes.
+func (z *tokenizer) tag() token {
+	t := token{typ: startTagToken}
+
+	z.pos++
+	if z.s[z.pos] == '/' {
+		t.typ = endTagToken
+		z.pos++
+	}
+
+	t.tag = strings.ToLower(z.readName())
+
+	for {
+		z.skipSpace()
+		if z.pos >= len(z.s) {
+			break
+		}
+
+		ch := z.s[z.pos]
+		if ch == '>' {
+			z.pos++
+			break
+		}
+		if ch == '/' {
+			z.pos++
+			if z.pos < len(z.s) && z.s[z.pos] == '>' {
+				z.pos++
+				if t.typ == startTagToken {
+					t.typ = selfClosingTagToken
+				}
+				break
+			}
+			continue
+		}
+
+		name := strings.ToLower(z.readName())
+		if name == "" {
+			// Garbage like a stray quote. Skip it.
+			z.pos++
+			continue
+		}
+
+		// An attribute without a value is a boolean one.
+		a := BoolAttr(name)
+		z.skipSpace()
+		if z.pos < len(z.s) && z.s[z.pos] == '=' {
+			z.pos++
+			z.skipSpace()
+			a = Attr(name, html.UnescapeString(z.readValue()))
+		}
+
+		if t.typ != endTagToken && !t.hasAttr(name) {
+			t.attrs = append(t.attrs, a)
+		}
+	}
+
+	if t.typ == startTagToken && rawTextElements[t.tag] {
+		z.rawTag = t.tag
+	}
+
+	return t
+}
+
+// Read a tag or attribute name.
+func (z *tokenizer) readName() string {
+	start := z.pos
+	for z.pos < len(z.s) {
+		ch := z.s[z.pos]
+		if isSpace(ch) || ch == '/' || ch == '>' || (ch == '=' && z.pos > start) {
+			break
+		}
+		z.pos++
+	}
+
+	return z.s[start:z.pos]
+}
+
+// Read a quoted or unquoted attribute value.
+func (z *tokenizer) readValue() string {
+	if z.pos >= len(z.s) {
+		return ""
+	}
+
+	quote := z.s[z.pos]
+	if quote == '"' || quote == '\'' {
+		start := z.pos + 1
+		end := strings.IndexByte(z.s[start:], quote)
+		if end < 0 {
+			z.pos = len(z.s)
+			return z.s[start:]
+		}
+		z.pos = start + end + 1
+		return z.s[start : start+end]
+	}
+
+	start := z.pos
+	for z.pos < len(z.s) && !isSpace(z.s[z.pos]) && z.s[z.pos] != '>' {
+		z.pos++
+	}
+
+	return z.s[start:z.pos]
+}
+
+func (z *tokenizer) skipSpace() {
+	for z.pos < len(z.s) && isSpace(z.s[z.pos]) {
+		z.pos++
+	}
+}
+
+// Return whether the token has an attribute with this name.
+func (t *token) hasAttr(name string) bool {
+	for _, a := range t.attrs {
+		if a.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '\f'
+}
+
+// Split s around runs of HTML whitespace. Unlike strings.Fields(), this
+// keeps non-breaking and other non-ASCII spaces.
+func htmlFields(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r < utf8.RuneSelf && isSpace(byte(r))
+	})
+}
+
+func isAsciiLetter(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
@@ -0,0 +1,406 @@
+package snake
+
+import (
+	"io"
+	"strings"
+)
+
+// Kinds of node in a tree built by NewTree().
+type NodeType int
+
+const (
+	// The root of a tree. Only has children.
+	DocumentNode NodeType = iota
+
+	// An element, with a tag, attributes, and children.
+	ElementNode
+
+	// Text, stored unescaped.
+	TextNode
+
+	// Markup written with RawText() and friends, stored as-is.
+	RawNode
+
+	// The <!DOCTYPE html> declaration.
+	DoctypeNode
+)
+
+// A node of a page built in memory. Use the exported fields to inspect it
+// and the methods to change it.
+type Node struct {
+	Type NodeType
+
+	// Element tag name.
+	Tag string
+
+	// Text of text and raw nodes.
+	Text string
+
+	Parent   *Node
+	Children []*Node
+
+	attrs []attr
+
+	// Whether the element was written with EmptyElement().
+	selfClosing bool
+}
+
+// Records the page as a tree of nodes.
+type treeBuilder struct {
+	root    *Node
+	current *Node
+}
+
+// Make a response that builds a tree of nodes in memory instead of writing
+// markup. Use the usual methods to build the page, then get the tree with
+// Tree(), change it, and serialize it with (*Node).Serialize(). Output
+// options like WithOutputMode() apply when serializing, not here.
+func NewTree(options ...Option) *HtmlResponse {
+	h := New(io.Discard, options...)
+	h.mode = OutputCompact
+
+	root := &Node{Type: DocumentNode}
+	h.tree = &treeBuilder{
+		root:    root,
+		current: root,
+	}
+
+	return h
+}
+
+// Return the root of the tree built by a response made with NewTree(), or
+// nil for a streaming response.
+func (h *HtmlResponse) Tree() *Node {
+	if h.tree == nil {
+		return nil
+	}
+
+	return h.tree.root
+}
+
+// Add a child to the current element.
+func (t *treeBuilder) add(n *Node) {
+	t.current.AppendChild(n)
+}
+
+// Add an element. If open, it becomes the current element.
+func (t *treeBuilder) addElement(tag string, attrs []attr, selfClosing, open bool) {
+	n := &Node{
+		Type:        ElementNode,
+		Tag:         tag,
+		attrs:       presentAttrs(attrs),
+		selfClosing: selfClosing,
+	}
+	t.add(n)
+
+	if open {
+		t.current = n
+	}
+}
+
+// Add text, merging it with the previous text node if there is one.
+func (t *treeBuilder) addText(nodeType NodeType, s string) {
+	children := t.current.Children
+	if len(children) > 0 {
+		last := children[len(children)-1]
+		if last.Type == nodeType {
+			last.Text += s
+			return
+		}
+	}
+
+	t.add(&Node{Type: nodeType, Text: s})
+}
+
+// Close the innermost open element with this tag, and any inside it.
+func (t *treeBuilder) closeElement(tag string) {
+	for n := t.current; n != nil && n.Type == ElementNode; n = n.Parent {
+		if n.Tag == tag {
+			t.current = n.Parent
+			return
+		}
+	}
+}
+
+// Make an element that's not yet part of a tree.
+func NewElement(tag string, attrs ...attr) *Node {
+	return &Node{
+		Type:  ElementNode,
+		Tag:   tag,
+		attrs: presentAttrs(attrs),
+	}
+}
+
+// Make a text node that's not yet part of a tree.
+func NewTextNode(s string) *Node {
+	return &Node{Type: TextNode, Text: s}
+}
+
+// Return the value of the attribute and whether it's present.
+func (n *Node) Attr(name string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.name == name {
+			return a.value, true
+		}
+	}
+
+	return "", false
+}
+
+// Return the attributes in order.
+func (n *Node) Attrs() []attr {
+	return n.attrs
+}
+
+// Set the attribute, replacing any previous value.
+func (n *Node) SetAttr(name, value string) *Node {
+	for i := range n.attrs {
+		if n.attrs[i].name == name {
+			n.attrs[i] = Attr(name, value)
+			return n
+		}
+	}
+
+	n.attrs = append(n.attrs, Attr(name, value))
+	return n
+}
+
+// Remove the attribute if it's present.
+func (n *Node) RemoveAttr(name string) *Node {
+	for i, a := range n.attrs {
+		if a.name == name {
+			n.attrs = append(n.attrs[:i], n.attrs[i+1:]...)
+			break
+		}
+	}
+
+	return n
+}
+
+// Mark the attributes of n and every element under it as trusted (see
+// Trust()), so that they're serialized as they are. Only use this on markup
+// you wrote or rendered yourself, never on parsed user input.
+func (n *Node) TrustAttrs() *Node {
+	n.Walk(func(c *Node) bool {
+		for i := range c.attrs {
+			c.attrs[i].trusted = true
+		}
+		return true
+	})
+
+	return n
+}
+
+// Return whether the element's class attribute includes class.
+func (n *Node) HasClass(class string) bool {
+	classes, _ := n.Attr("class")
+
+	for _, c := range strings.Fields(classes) {
+		if c == class {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Add the class to the element's class attribute if it's not already there.
+func (n *Node) AddClass(class string) *Node {
+	if n.HasClass(class) {
+		return n
+	}
+
+	classes, _ := n.Attr("class")
+	return n.SetAttr("class", strings.TrimSpace(classes+" "+class))
+}
+
+// Remove the class from the element's class attribute.
+func (n *Node) RemoveClass(class string) *Node {
+	classes, ok := n.Attr("class")
+	if !ok {
+		return n
+	}
+
+	var kept []string
+	for _, c := range strings.Fields(classes) {
+		if c != class {
+			kept = append(kept, c)
+		}
+	}
+
+	if len(kept) == 0 {
+		return n.RemoveAttr("class")
+	}
+	return n.SetAttr("class", strings.Join(kept, " "))
+}
+
+// Detach the node from its parent.
+func (n *Node) Remove() *Node {
+	if n.Parent != nil {
+		siblings := n.Parent.Children
+		for i, c := range siblings {
+			if c == n {
+				n.Parent.Children = append(siblings[:i:i], siblings[i+1:]...)
+				break
+			}
+		}
+		n.Parent = nil
+	}
+
+	return n
+}
+
+// Add the child at the end of n's children, moving it from its old parent.
+func (n *Node) AppendChild(child *Node) *Node {
+	child.Remove()
+	child.Parent = n
+	n.Children = append(n.Children, child)
+
+	return n
+}
+
+// Add the child at the start of n's children, moving it from its old parent.
+func (n *Node) PrependChild(child *Node) *Node {
+	child.Remove()
+	child.Parent = n
+	n.Children = append([]*Node{child}, n.Children...)
+
+	return n
+}
+
+// Add the node just before ref, which must be a child of n.
+func (n *Node) InsertBefore(child, ref *Node) *Node {
+	child.Remove()
+	child.Parent = n
+
+	for i, c := range n.Children {
+		if c == ref {
+			n.Children = append(n.Children[:i:i], append([]*Node{child}, n.Children[i:]...)...)
+			return n
+		}
+	}
+
+	n.Children = append(n.Children, child)
+	return n
+}
+
+// Render the component and add the nodes it makes at the end of n's
+// children:
+//
+//	body.AppendComponent(ComponentFunc(func(h *HtmlResponse) {
+//		h.Div(Class("debug")).Content(timing)
+//	}))
+func (n *Node) AppendComponent(c Component) *Node {
+	t := NewTree()
+	t.Render(c)
+
+	for _, child := range append([]*Node(nil), t.Tree().Children...) {
+		n.AppendChild(child)
+	}
+
+	return n
+}
+
+// Call f for n and each node under it, in document order. If f returns
+// false, the node's children are skipped.
+func (n *Node) Walk(f func(n *Node) bool) {
+	if f(n) {
+		for _, c := range append([]*Node(nil), n.Children...) {
+			c.Walk(f)
+		}
+	}
+}
+
+// Return the elements under n (including n) for which match returns true.
+func (n *Node) FindAll(match func(n *Node) bool) []*Node {
+	var found []*Node
+
+	n.Walk(func(c *Node) bool {
+		if c.Type == ElementNode && match(c) {
+			found = append(found, c)
+		}
+		return true
+	})
+
+	return found
+}
+
+// Return the first element with the id, or nil.
+func (n *Node) FindById(id string) *Node {
+	found := n.FindAll(func(c *Node) bool {
+		value, ok := c.Attr("id")
+		return ok && value == id
+	})
+
+	if len(found) == 0 {
+		return nil
+	}
+	return found[0]
+}
+
+// Return the elements with the class.
+func (n *Node) FindByClass(class string) []*Node {
+	return n.FindAll(func(c *Node) bool {
+		return c.HasClass(class)
+	})
+}
+
+// Return the elements with the tag.
+func (n *Node) FindByTag(tag string) []*Node {
+	return n.FindAll(func(c *Node) bool {
+		return c.Tag == tag
+	})
+}
+
+// Return the text of the node and everything under it.
+func (n *Node) TextContent() string {
+	var b strings.Builder
+
+	n.Walk(func(c *Node) bool {
+		if c.Type == TextNode {
+			b.WriteString(c.Text)
+		}
+		return true
+	})
+
+	return b.String()
+}
+
+// Write the node and everything under it to h, as if the original calls
+// were made again. This makes a Node a Component.
+func (n *Node) Render(h *HtmlResponse) {
+	switch n.Type {
+	case DocumentNode:
+		for _, c := range n.Children {
+			c.Render(h)
+		}
+	case ElementNode:
+		switch {
+		case n.selfClosing:
+			h.EmptyElement(n.Tag, n.attrs...)
+		case voidElements[n.Tag] && len(n.Children) == 0:
+			h.singleTag(n.Tag, n.attrs)
+		default:
+			h.openTag(n.Tag, n.attrs)
+			for _, c := range n.Children {
+				c.Render(h)
+			}
+			h.closeTag(n.Tag)
+		}
+	case TextNode:
+		h.Text(n.Text)
+	case RawNode:
+		h.RawText(n.Text)
+	case DoctypeNode:
+		h.Doctype()
+	}
+}
+
+// Write the node and everything under it as markup, with the options of a
+// streaming response.
+func (n *Node) Serialize(w io.Writer, options ...Option) error {
+	h := New(w, options...)
+	n.Render(h)
+
+	return h.Close()
+}
@@ -0,0 +1,145 @@
+package snake
+
+import (
+	"strings"
+)
+
+// How an HtmlResponse reacts to mismatched or unclosed tags.
+type Validation int
+
+const (
+	// Panic on the first tag error. This is the default.
+	ValidateStrict Validation = iota
+
+	// Record tag errors and keep rendering. Retrieve them with Err() or Close().
+	ValidateCollect
+
+	// Don't check tag pairing at all.
+	ValidateOff
+)
+
+// Set the validation policy of the response.
+func WithValidation(v Validation) Option {
+	return func(h *HtmlResponse) {
+		h.validation = v
+	}
+}
+
+// A tag pairing problem, with the path of open elements where it was found.
+type TagError struct {
+	Message string
+
+	// Open elements at the point of the error, such as "html > body > div#main".
+	Path string
+}
+
+func (e *TagError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+
+	return e.Message + " at " + e.Path
+}
+
+// An element that has been opened but not yet closed.
+type element struct {
+	tag   string
+	id    string
+	class string
+
+	// For pretty-printing, whether a block element was written inside this one.
+	hasBlockChild bool
+}
+
+// Make an element for the stack, remembering its id and class for error paths.
+func newElement(tag string, attrs []attr) *element {
+	e := &element{tag: tag}
+
+	for _, a := range attrs {
+		switch a.name {
+		case "id":
+			e.id = a.value
+		case "class":
+			e.class = a.value
+		}
+	}
+
+	return e
+}
+
+// Return the element in CSS selector form, such as "div#main.left.wide".
+func (e *element) String() string {
+	s := e.tag
+	if e.id != "" {
+		s += "#" + e.id
+	}
+	for _, class := range strings.Fields(e.class) {
+		s += "." + class
+	}
+
+	return s
+}
+
+// Return the path of currently-open elements, outermost first.
+func (h *HtmlResponse) path() string {
+	var parts []string
+
+	for e := h.stack.Front(); e != nil; e = e.Next() {
+		parts = append(parts, e.Value.(*element).String())
+	}
+
+	return strings.Join(parts, " > ")
+}
+
+// Report a tag error according to the validation policy.
+func (h *HtmlResponse) tagError(message string) {
+	err := &TagError{
+		Message: message,
+		Path:    h.path(),
+	}
+
+	switch h.validation {
+	case ValidateStrict:
+		panic(err)
+	case ValidateCollect:
+		h.tagErrors = append(h.tagErrors, err)
+	}
+}
+
+// Pop tag off the stack, reporting an error if it's not the innermost open
+// element. If it's open further out, the elements inside it are popped too,
+// the way a browser would recover.
+func (h *HtmlResponse) popTag(tag string) {
+	back := h.stack.Back()
+	if back == nil {
+		h.tagError("too many closing tags (/" + tag + ")")
+		return
+	}
+
+	backTag := back.Value.(*element).tag
+	if backTag == tag {
+		h.stack.Remove(back)
+		return
+	}
+
+	h.tagError("mismatched tag (/" + tag + ", expected /" + backTag + ")")
+
+	for e := back; e != nil; e = e.Prev() {
+		if e.Value.(*element).tag == tag {
+			for h.stack.Back() != e {
+				h.stack.Remove(h.stack.Back())
+			}
+			h.stack.Remove(e)
+			return
+		}
+	}
+}
+
+// Report every element that's still open.
+func (h *HtmlResponse) checkUnclosed() {
+	for h.stack.Len() > 0 {
+		back := h.stack.Back()
+		h.tagError("unclosed tag <" + back.Value.(*element).tag + ">")
+		h.stack.Remove(back)
+	}
+}
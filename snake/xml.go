@@ -0,0 +1,82 @@
+package snake
+
+import (
+	"strings"
+)
+
+// Namespaces for use with Xmlns() and XmlnsPrefix().
+const (
+	SvgNamespace    = "http://www.w3.org/2000/svg"
+	MathMLNamespace = "http://www.w3.org/1998/Math/MathML"
+	XLinkNamespace  = "http://www.w3.org/1999/xlink"
+	AtomNamespace   = "http://www.w3.org/2005/Atom"
+)
+
+// Write XML instead of HTML: void elements are self-closing ("<br/>"),
+// every element counts as a block for pretty-printing, and closing tags are
+// never omitted.
+func WithXml() Option {
+	return func(h *HtmlResponse) {
+		h.xml = true
+	}
+}
+
+// Write the XML declaration. It must come first in the document.
+func (h *HtmlResponse) XmlDeclaration() *HtmlResponse {
+	h.WriteStr(`<?xml version="1.0" encoding="UTF-8"?>`)
+	if h.mode != OutputMinified {
+		h.WriteStr("\n")
+	}
+	return h
+}
+
+// Write the text in a CDATA section, splitting it if it contains "]]>".
+func (h *HtmlResponse) CData(s string) *HtmlResponse {
+	h.WriteStr("<![CDATA[")
+	h.WriteStr(strings.Replace(s, "]]>", "]]]]><![CDATA[>", -1))
+	h.WriteStr("]]>")
+	return h
+}
+
+// Open an element that has no method of its own, such as an element of an
+// XML vocabulary. Close it with Element_().
+func (h *HtmlResponse) Element(tag string, attrs ...attr) *HtmlResponse {
+	return h.openTag(tag, attrs)
+}
+
+// Close an element opened with Element().
+func (h *HtmlResponse) Element_(tag string) *HtmlResponse {
+	return h.closeTag(tag)
+}
+
+// Write a self-closing element ("<tag/>"). In HTML this is only valid for
+// SVG and MathML elements.
+func (h *HtmlResponse) EmptyElement(tag string, attrs ...attr) *HtmlResponse {
+	if h.tree != nil {
+		h.tree.addElement(tag, attrs, true, false)
+		return h
+	}
+
+	h.formatBeforeTag(tag)
+	h.writeTag(tag, attrs, true)
+	if h.lint != nil {
+		h.lint.addElement(tag, attrs, true, false)
+	}
+	return h
+}
+
+// Make the default namespace declaration.
+func Xmlns(uri string) attr {
+	return Attr("xmlns", uri)
+}
+
+// Make a namespace declaration for the prefix, such as
+// XmlnsPrefix("xlink", XLinkNamespace).
+func XmlnsPrefix(prefix, uri string) attr {
+	return Attr("xmlns:"+prefix, uri)
+}
+
+// Make an attribute in a namespace, such as NsAttr("xlink", "href", "#a").
+func NsAttr(prefix, name, value string) attr {
+	return Attr(prefix+":"+name, value)
+}
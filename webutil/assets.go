@@ -0,0 +1,26 @@
+package webutil
+
+import (
+	"github.com/lkesteloot/goutil/snake"
+	"net/http"
+	"strings"
+)
+
+// Handler that serves the hashed files of an asset manifest with far-future
+// cache headers, since a hashed file never changes. Any other path gets a
+// 404. The handler expects the hashed name as the path, so strip the URL
+// prefix given to snake.LoadAssets():
+//
+//	http.Handle("/assets/", http.StripPrefix("/assets/", webutil.AssetHandler(assets)))
+func AssetHandler(assets *snake.AssetManifest) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filePath, ok := assets.FilePath(strings.TrimPrefix(r.URL.Path, "/"))
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		http.ServeFile(w, r, filePath)
+	})
+}
@@ -32,6 +32,13 @@ func (s *StatusResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	return (s.w.(http.Hijacker)).Hijack()
 }
 
+// For interface net/http/Flusher, so that handlers can stream responses:
+func (s *StatusResponseWriter) Flush() {
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 func (s *StatusResponseWriter) StatusCode() int {
 	if s.statusCode == 0 {
 		// It's 0 if it's not been set, but then the http lib changes that to 200.